@@ -0,0 +1,133 @@
+//go:build err2_noassert
+
+package assert_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lainio/err2/assert"
+)
+
+// The err2_noassert build must keep the exported API of the assert package,
+// i.e., the client packages compile with and without the tag. This table
+// fails the compilation if the build drops or changes some of it.
+var (
+	_ func(f func()) (err error)                                        = assert.Collect
+	_ func(str, substr string, a ...any)                                = assert.Contains
+	_ func() map[string]int64                                           = assert.Counts
+	_ func(val, want any, a ...any)                                     = assert.DeepEqual
+	_ func(obj string, a ...any)                                        = assert.Empty
+	_ func(cond func() bool, a ...any)                                  = assert.Ensure
+	_ func(err error, a ...any)                                         = assert.Error
+	_ func(err error, substr string, a ...any)                          = assert.ErrorContains
+	_ func(err, target error, a ...any)                                 = assert.ErrorIs
+	_ func(cond func() bool, timeout, interval time.Duration, a ...any) = assert.Eventually
+	_ func(f func())                                                    = assert.Go
+	_ func(str, prefix string, a ...any)                                = assert.HasPrefix
+	_ func(str, suffix string, a ...any)                                = assert.HasSuffix
+	_ func(i any, a ...any)                                             = assert.INil
+	_ func(i any, a ...any)                                             = assert.INotNil
+	_ func(obj string, length int, a ...any)                            = assert.Len
+	_ func(s string, length int, a ...any)                              = assert.Longer
+	_ func(str, pattern string, a ...any)                               = assert.Matches
+	_ func(cond func() bool, timeout, interval time.Duration, a ...any) = assert.Never
+	_ func(err error, a ...any)                                         = assert.NoError
+	_ func(val, want any, a ...any)                                     = assert.NotDeepEqual
+	_ func(obj string, a ...any)                                        = assert.NotEmpty
+	_ func(a ...any)                                                    = assert.NotImplemented
+	_ func(f func(), a ...any)                                          = assert.NotPanics
+	_ func(f func(), a ...any)                                          = assert.Panics
+	_ func(f func(), match func(r any) bool, a ...any)                  = assert.PanicsWith
+	_ func()                                                            = assert.PopAsserter
+	_ func()                                                            = assert.PopCollector
+	_ func()                                                            = assert.PopTester
+	_ func(term bool, a ...any)                                         = assert.Require
+	_ func()                                                            = assert.ResetCounts
+	_ func(pkgPath string)                                              = assert.ResetPackageDefault
+	_ func(a assert.Asserter, on bool) (old bool)                       = assert.SetContracts
+	_ func(str string, length int, a ...any)                            = assert.Shorter
+	_ func(term bool, a ...any)                                         = assert.That
+	_ func(term bool, a ...any)                                         = assert.ThatNot
+	_ func(t testing.TB, a ...assert.Asserter)                          = assert.UseTester
+	_ func(f func(v *assert.Validation)) error                          = assert.Validate
+	_ func(h assert.Hook) (remove func())                               = assert.AddHook
+	_ func(obj assert.Invariant, a ...any) func()                       = assert.CheckInvariant
+	_ func(i assert.Asserter) (retFn func())                            = assert.PushAsserter
+	_ func(c assert.Clock) (pop func())                                 = assert.PushClock
+	_ func() func()                                                     = assert.PushCollector
+	_ func(t testing.TB, a ...assert.Asserter) func()                   = assert.PushTester
+	_ func(r assert.Reporter) assert.Asserter                           = assert.Register
+	_ func(i assert.Asserter) (old assert.Asserter)                     = assert.SetDefault
+	_ func(pkgPath string, i assert.Asserter) (old assert.Asserter)     = assert.SetPackageDefault
+	_ func(c assert.Clock) (old assert.Clock)                           = assert.SetClock
+	_ func(a assert.Asserter) assert.Reporter                           = assert.ReporterOf
+
+	_ func(val, lo, hi int, a ...any)                                           = assert.Between[int]
+	_ func(val, lo, hi int, a ...any)                                           = assert.BetweenExclusive[int]
+	_ func(obj chan int, length int, a ...any)                                  = assert.CLen[chan int, int]
+	_ func(obj chan int, length int, a ...any)                                  = assert.CLonger[chan int, int]
+	_ func(c chan int, a ...any)                                                = assert.CNil[chan int, int]
+	_ func(c chan int, a ...any)                                                = assert.CNotNil[chan int, int]
+	_ func(obj chan int, length int, a ...any)                                  = assert.CShorter[chan int, int]
+	_ func(val, want int, a ...any)                                             = assert.Equal[int]
+	_ func(val, want int, eq func(a, b int) bool, a ...any)                     = assert.EqualFunc[int]
+	_ func(val, want time.Time, a ...any)                                       = assert.EqualMethod[time.Time]
+	_ func(err error, a ...any) (val int)                                       = assert.ErrorAs[int]
+	_ func(get func() int, want int, timeout, interval time.Duration, a ...any) = assert.EventuallyEqual[int]
+	_ func(name string, got string, a ...any)                                   = assert.Golden[string]
+	_ func(val, want int, a ...any)                                             = assert.Greater[int]
+	_ func(val, want, delta int, a ...any)                                      = assert.InDelta[int]
+	_ func(val, want int, epsilon float64, a ...any)                            = assert.InEpsilon[int]
+	_ func(got, want string, a ...any)                                          = assert.JSONEq[string]
+	_ func(got, want string, ignore []string, a ...any)                         = assert.JSONEqIgnore[string]
+	_ func(val, want int, a ...any)                                             = assert.Less[int]
+	_ func(obj map[int]int, a ...any)                                           = assert.MEmpty[map[int]int, int, int]
+	_ func(obj map[int]int, key int, a ...any) (val int)                        = assert.MKeyExists[map[int]int, int, int]
+	_ func(obj map[int]int, key int, a ...any)                                  = assert.MKeyNotExists[map[int]int, int, int]
+	_ func(obj map[int]int, length int, a ...any)                               = assert.MLen[map[int]int, int, int]
+	_ func(obj map[int]int, length int, a ...any)                               = assert.MLonger[map[int]int, int, int]
+	_ func(m map[int]int, a ...any)                                             = assert.MNil[map[int]int, int, int]
+	_ func(obj map[int]int, a ...any)                                           = assert.MNotEmpty[map[int]int, int, int]
+	_ func(m map[int]int, a ...any)                                             = assert.MNotNil[map[int]int, int, int]
+	_ func(obj map[int]int, length int, a ...any)                               = assert.MShorter[map[int]int, int, int]
+	_ func(obj map[int]int, key int, want int, a ...any)                        = assert.MValueEquals[map[int]int, int, int]
+	_ func(val int, a ...any)                                                   = assert.Negative[int]
+	_ func(p *int, a ...any)                                                    = assert.Nil[int]
+	_ func(val, want int, a ...any)                                             = assert.NotEqual[int]
+	_ func(p *int, a ...any)                                                    = assert.NotNil[*int, int]
+	_ func(val int, a ...any)                                                   = assert.NotZero[int]
+	_ func(val int, a ...any)                                                   = assert.Positive[int]
+	_ func(obj []int, elem int, a ...any)                                       = assert.SContains[[]int, int]
+	_ func(obj, want []int, a ...any)                                           = assert.SElementsMatch[[]int, int]
+	_ func(obj []int, a ...any)                                                 = assert.SEmpty[[]int, int]
+	_ func(obj []int, length int, a ...any)                                     = assert.SLen[[]int, int]
+	_ func(obj []int, length int, a ...any)                                     = assert.SLonger[[]int, int]
+	_ func(s []int, a ...any)                                                   = assert.SNil[[]int, int]
+	_ func(obj []int, elem int, a ...any)                                       = assert.SNotContains[[]int, int]
+	_ func(obj []int, a ...any)                                                 = assert.SNotEmpty[[]int, int]
+	_ func(s []int, a ...any)                                                   = assert.SNotNil[[]int, int]
+	_ func(obj []int, length int, a ...any)                                     = assert.SShorter[[]int, int]
+	_ func(obj []int, a ...any)                                                 = assert.SSorted[[]int, int]
+	_ func(obj []int, less func(a, b int) bool, a ...any)                       = assert.SSortedFunc[[]int, int]
+	_ func(obj, sub []int, a ...any)                                            = assert.SSubset[[]int, int]
+	_ func(obj []int, a ...any)                                                 = assert.SUnique[[]int, int]
+	_ func(val int, a ...any)                                                   = assert.Zero[int]
+
+	_ = []assert.Asserter{assert.Plain, assert.Production, assert.Development,
+		assert.Test, assert.TestFull, assert.Debug}
+	_ error                     = assert.ErrAssertion
+	_ error                     = (*assert.AssertionError)(nil)
+	_ func(error) bool          = (*assert.AssertionError)(nil).Is
+	_ error                     = assert.ValidationErrors(nil)
+	_ func(error) bool          = assert.ValidationErrors(nil).Is
+	_ error                     = assert.Violations(nil)
+	_ func() []error            = assert.Violations(nil).Unwrap
+	_ func(string, func())      = (*assert.Validation)(nil).Field
+	_ func() string             = assert.Violation{}.String
+	_ assert.Hook               = func(v assert.Violation) {}
+	_ assert.Clock              = nil
+	_ assert.Reporter           = nil
+	_ assert.Invariant          = nil
+	_ assert.Equaler[time.Time] = time.Time{}
+)
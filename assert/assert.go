@@ -1,15 +1,25 @@
 package assert
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"math"
 	"os"
+	"path"
 	"reflect"
+	"regexp"
 	"runtime"
+	rdebug "runtime/debug"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 
+	"github.com/lainio/err2/catalog"
+	msgs "github.com/lainio/err2/internal/catalog"
 	"github.com/lainio/err2/internal/debug"
+	"github.com/lainio/err2/internal/diff"
 	"github.com/lainio/err2/internal/x"
 	"golang.org/x/exp/constraints"
 )
@@ -84,6 +94,10 @@ type Asserter = uint32
 //
 //	assert.NotNil(p)
 //
+// You can implement your own [Asserter], e.g., to send the violations to your
+// metrics, by implementing the [Reporter] interface and registering it with
+// [Register].
+//
 // [nvim-go]: https://github.com/lainio/nvim-go
 const (
 	Plain Asserter = 0 + iota
@@ -129,18 +143,25 @@ var (
 	mu sync.Mutex
 
 	asserterFlag flagAsserter
+
+	// updateGolden is the -err2-update flag that rewrites the golden files.
+	updateGolden bool
 )
 
 func init() {
 	SetDefault(Production)
-	flag.Var(&asserterFlag, "asserter", "`asserter`: Plain, Prod, Dev, Debug")
+	flag.Var(&asserterFlag, "asserter", "`asserter`: Plain, Prod, Dev, Test, TestFull, Debug")
+	flag.BoolVar(&updateGolden, updateFlag, false, "update the golden files of assert.Golden")
 }
 
 type (
 	mapAsserter = map[int]asserter
+	regexpMap   = map[string]*regexp.Regexp
 
 	testersMap = map[int]testing.TB
-	function   = func()
+
+	pkgAsserterMap = map[string]Asserter
+	function       = func()
 )
 
 var (
@@ -148,23 +169,35 @@ var (
 	testers = x.NewRWMap[testersMap]()
 
 	asserterMap = x.NewRWMap[mapAsserter]()
+
+	// pkgDefaults are the package default asserters by the import paths.
+	pkgDefaults = x.NewRWMap[pkgAsserterMap]()
+
+	// regexps caches the compiled patterns of Matches.
+	regexps = x.NewRWMap[regexpMap]()
 )
 
 const (
 	assertionNot = "not"
 
-	assertionMsg         = "assertion failure"
-	assertionEqualMsg    = "assertion failure: equal"
-	assertionNotEqualMsg = "assertion failure: not equal"
-	assertionLenMsg      = "assertion failure: length"
-
-	gotWantFmt        = ": got '%v', want '%v'"
-	gotWantLongerFmt  = ": got '%v', should be longer than '%v'"
-	gotWantShorterFmt = ": got '%v', should be shorter than '%v'"
-
 	conCatErrStr = ": "
 )
 
+// msg returns the message of the ID from the current message catalog. See
+// err2.SetCatalog for more information.
+func msg(id catalog.ID) string {
+	return msgs.Msg(id)
+}
+
+// assertionMsg returns 'assertion failure' message, and if the ID is given
+// the message is concatenated to it, e.g., 'assertion failure: equal'.
+func assertionMsg(id ...catalog.ID) string {
+	if len(id) == 0 {
+		return msg(catalog.Assertion)
+	}
+	return msg(catalog.Assertion) + conCatErrStr + msg(id[0])
+}
+
 // PushTester sets the current testing context for default [Asserter]. This must
 // be called at the beginning of every test. There is two way of doing it:
 //
@@ -237,6 +270,9 @@ func PopTester() {
 	if r == nil {
 		return
 	}
+	if tester() == nil {
+		panic(r) // the goroutine ID is stale, see UseTester
+	}
 
 	var stackLvl = 5     // amount of functions before we're here
 	var framesToSkip = 3 // how many fn calls there is before FuncName call
@@ -264,10 +300,87 @@ func PopTester() {
 	// Now that call stack errors are printed, if any. Let's print the actual
 	// line that caused the error, i.e., was throwing the error. Note that we
 	// are here in the 'catch-function'.
-	fatal("assertion catching: "+msg, framesToSkip)
+	fatal(msgs.Msg(catalog.AssertionCatching)+conCatErrStr+msg, framesToSkip)
+}
+
+// UseTester is like [PushTester] but it registers the cleanup with
+// t.Cleanup, i.e., you cannot forget to pop the testing context:
+//
+//	func TestInvite(t *testing.T) {
+//	     assert.UseTester(t) // no defer, no ()
+//	     ...
+//	     assert.Go(func() { // inherits the testing context of t
+//	          assert.Equal(alice.Len(), 1)
+//	     })
+//
+// The cleanup removes the testing context from the goroutines started with
+// [Go] as well. If such a goroutine is still running when the test ends, its
+// goroutine ID is stale, and its assertion violations aren't reported to the
+// finished test anymore but they panic like in the runtime.
+//
+// See [PushTester] for the optional [Asserter] argument.
+func UseTester(t testing.TB, a ...Asserter) {
+	PushTester(t, a...)
+	t.Cleanup(func() {
+		popTesters(t)
+	})
+}
+
+// Go starts the function f in a new goroutine that inherits the testing
+// context of the current goroutine, i.e., you don't need to call
+// [PushTester] in it. The testing context is popped when f returns, and the
+// panics of f are caught like [PopTester] does. If the current goroutine
+// doesn't have a testing context, Go is same as the go statement.
+//
+// Note that the test doesn't wait for the goroutines. Use e.g. sync.WaitGroup
+// to wait them before the test ends. See [UseTester] for the details.
+func Go(f func()) {
+	t := tester()
+	if t == nil {
+		go f()
+		return
+	}
+	go func() {
+		if !inheritTester(goid(), t) {
+			f() // the test has ended, i.e., we have nothing to inherit
+			return
+		}
+		defer PopTester()
+		f()
+	}()
+}
+
+// inheritTester sets t to the testing context of the goroutine gid if some
+// other goroutine still has it. That prevents the stale goroutine IDs, i.e.,
+// the test of t has ended already.
+func inheritTester(gid int, t testing.TB) (ok bool) {
+	testers.Tx(func(m testersMap) {
+		for _, tt := range m {
+			if tt == t {
+				m[gid] = t
+				ok = true
+				return
+			}
+		}
+	})
+	return ok
+}
+
+// popTesters removes the testing context of t from all of the goroutines.
+func popTesters(t testing.TB) {
+	testers.Tx(func(m testersMap) {
+		for gid, tt := range m {
+			if tt == t {
+				delete(m, gid)
+			}
+		}
+	})
 }
 
 func tester() (t testing.TB) {
+	if testers.Len() == 0 {
+		return nil // fast path: no goid() needed
+	}
 	return testers.Get(goid())
 }
 
@@ -277,7 +390,11 @@ func tester() (t testing.TB) {
 // [SetDefault]), optional arguments are used to override the auto-generated
 // assert violation message.
 func NotImplemented(a ...any) {
-	current().reportAssertionFault(0, assertionMsg+": not implemented", a)
+	if !enabled {
+		return
+	}
+	defMsg := assertionMsg(catalog.NotImplemented)
+	current().reportAssertionFault(0, defMsg, a)
 }
 
 // ThatNot asserts that the term is NOT true. If is it panics with the given
@@ -288,7 +405,7 @@ func NotImplemented(a ...any) {
 // [SetDefault]), optional arguments are used to override the auto-generated
 // assert violation message.
 func ThatNot(term bool, a ...any) {
-	if term {
+	if enabled && term {
 		doThat(a)
 	}
 }
@@ -301,13 +418,13 @@ func ThatNot(term bool, a ...any) {
 // [SetDefault]), optional arguments are used to override the auto-generated
 // assert violation message.
 func That(term bool, a ...any) {
-	if !term {
+	if enabled && !term {
 		doThat(a)
 	}
 }
 
 func doThat(a []any) {
-	defMsg := assertionMsg
+	defMsg := assertionMsg()
 	current().reportAssertionFault(1, defMsg, a)
 }
 
@@ -318,8 +435,8 @@ func doThat(a []any) {
 // [SetDefault]), optional arguments are used to override the auto-generated
 // assert violation message.
 func NotNil[P ~*T, T any](p P, a ...any) {
-	if p == nil {
-		doNamed("not", "pointer", "nil", a)
+	if enabled && p == nil {
+		doNamed("not", catalog.TypePointer, catalog.Nil, a)
 	}
 }
 
@@ -330,8 +447,8 @@ func NotNil[P ~*T, T any](p P, a ...any) {
 // [SetDefault]), optional arguments are used to override the auto-generated
 // assert violation message.
 func Nil[T any](p *T, a ...any) {
-	if p != nil {
-		doNamed("", "pointer", "nil", a)
+	if enabled && p != nil {
+		doNamed("", catalog.TypePointer, catalog.Nil, a)
 	}
 }
 
@@ -348,8 +465,8 @@ func Nil[T any](p *T, a ...any) {
 //
 // [the interface type]: https://go.dev/doc/faq#nil_error
 func INil(i any, a ...any) {
-	if i != nil {
-		doNamed("", "interface", "nil", a)
+	if enabled && i != nil {
+		doNamed("", catalog.TypeInterface, catalog.Nil, a)
 	}
 }
 
@@ -366,8 +483,8 @@ func INil(i any, a ...any) {
 //
 // [the interface type]: https://go.dev/doc/faq#nil_error
 func INotNil(i any, a ...any) {
-	if i == nil {
-		doNamed("not", "interface", "nil", a)
+	if enabled && i == nil {
+		doNamed("not", catalog.TypeInterface, catalog.Nil, a)
 	}
 }
 
@@ -378,8 +495,8 @@ func INotNil(i any, a ...any) {
 // [SetDefault]), optional arguments are used to override the auto-generated
 // assert violation message.
 func SNil[S ~[]T, T any](s S, a ...any) {
-	if s != nil {
-		doNamed("", "slice", "nil", a)
+	if enabled && s != nil {
+		doNamed("", catalog.TypeSlice, catalog.Nil, a)
 	}
 }
 
@@ -390,8 +507,8 @@ func SNil[S ~[]T, T any](s S, a ...any) {
 // [SetDefault]), optional arguments are used to override the auto-generated
 // assert violation message.
 func CNil[C ~chan T, T any](c C, a ...any) {
-	if c != nil {
-		doNamed("", "channel", "nil", a)
+	if enabled && c != nil {
+		doNamed("", catalog.TypeChannel, catalog.Nil, a)
 	}
 }
 
@@ -402,8 +519,8 @@ func CNil[C ~chan T, T any](c C, a ...any) {
 // [SetDefault]), optional arguments are used to override the auto-generated
 // assert violation message.
 func MNil[M ~map[T]U, T comparable, U any](m M, a ...any) {
-	if m != nil {
-		doNamed("", "map", "nil", a)
+	if enabled && m != nil {
+		doNamed("", catalog.TypeMap, catalog.Nil, a)
 	}
 }
 
@@ -414,8 +531,8 @@ func MNil[M ~map[T]U, T comparable, U any](m M, a ...any) {
 // [SetDefault]), optional arguments are used to override the auto-generated
 // assert violation message.
 func SNotNil[S ~[]T, T any](s S, a ...any) {
-	if s == nil {
-		doNamed("not", "slice", "nil", a)
+	if enabled && s == nil {
+		doNamed("not", catalog.TypeSlice, catalog.Nil, a)
 	}
 }
 
@@ -426,8 +543,8 @@ func SNotNil[S ~[]T, T any](s S, a ...any) {
 // [SetDefault]), optional arguments are used to override the auto-generated
 // assert violation message.
 func CNotNil[C ~chan T, T any](c C, a ...any) {
-	if c == nil {
-		doNamed("not", "channel", "nil", a)
+	if enabled && c == nil {
+		doNamed("not", catalog.TypeChannel, catalog.Nil, a)
 	}
 }
 
@@ -438,8 +555,8 @@ func CNotNil[C ~chan T, T any](c C, a ...any) {
 // [SetDefault]), optional arguments are used to override the auto-generated
 // assert violation message.
 func MNotNil[M ~map[T]U, T comparable, U any](m M, a ...any) {
-	if m == nil {
-		doNamed("not", "map", "nil", a)
+	if enabled && m == nil {
+		doNamed("not", catalog.TypeMap, catalog.Nil, a)
 	}
 }
 
@@ -454,32 +571,47 @@ func MNotNil[M ~map[T]U, T comparable, U any](m M, a ...any) {
 // Note, when [Asserter] is [Plain], optional arguments are used to build a new
 // assert violation message.
 func NotEqual[T comparable](val, want T, a ...any) {
-	if want == val {
-		doShouldNotBeEqual(assertionNotEqualMsg, val, want, a)
+	if enabled && want == val {
+		doShouldNotBeEqual(catalog.NotEqual, val, want, a)
 	}
 }
 
 // Equal asserts that the values are equal. If not it panics/errors (according
 // the current [Asserter]) with the auto-generated message. You can append the
-// generated got-want message by using optional message arguments.
+// generated got-want message by using optional message arguments. For structs
+// and multi-line strings the message includes only the differences of the
+// values, see [DeepEqual].
 //
 // Note that when [Plain] [Asserter] is used ([PushAsserter] or even
 // [SetDefault]), optional arguments are used to override the auto-generated
 // assert violation message.
 func Equal[T comparable](val, want T, a ...any) {
-	if want != val {
-		doShouldBeEqual(assertionEqualMsg, val, want, a)
+	if enabled && want != val {
+		doShouldBeEqual(catalog.Equal, val, want, a)
 	}
 }
 
-func doShouldBeEqual[T comparable](aname string, val, want T, a []any) {
-	defMsg := fmt.Sprintf(aname+gotWantFmt, val, want)
-	current().reportAssertionFault(1, defMsg, a)
+func doShouldBeEqual[T comparable](aname catalog.ID, val, want T, a []any) {
+	defMsg := gotWantMsg(assertionMsg(aname), val, want)
+	current().reportGotWant(1, defMsg, val, want, a)
 }
 
-func doShouldNotBeEqual[T comparable](aname string, val, want T, a []any) {
-	defMsg := fmt.Sprintf(aname+": got '%v' want (!= '%v')", val, want)
-	current().reportAssertionFault(1, defMsg, a)
+// gotWantMsg builds the got-want message. If the values are composite values
+// or multi-line strings, the message includes only the differences of them,
+// see [DeepEqual].
+func gotWantMsg(aname string, val, want any) string {
+	if diff.IsRich(val, want) {
+		if d := diff.Values(val, want); d != "" {
+			return fmt.Sprintf(aname+msg(catalog.GotWantDiff), d)
+		}
+	}
+	return fmt.Sprintf(aname+msg(catalog.GotWant), val, want)
+}
+
+func doShouldNotBeEqual[T comparable](aname catalog.ID, val, want T, a []any) {
+	f := assertionMsg(aname) + msg(catalog.GotWantNotEqual)
+	defMsg := fmt.Sprintf(f, val, want)
+	current().reportGotWant(1, defMsg, val, want, a)
 }
 
 // DeepEqual asserts that the (whatever) values are equal. If not it
@@ -487,13 +619,20 @@ func doShouldNotBeEqual[T comparable](aname string, val, want T, a []any) {
 // message. You can append the generated got-want message by using optional
 // message arguments.
 //
+// For structs, slices, maps, pointers and multi-line strings the message
+// includes only the differing paths of the values instead of the whole
+// values, and long multi-line strings are shown as a unified line diff:
+//
+//	assertion failure: got and want differ:
+//	  .Items[3].Name: "a" != "b"
+//
 // Note that when [Plain] [Asserter] is used ([PushAsserter] or even
 // [SetDefault]), optional arguments are used to override the auto-generated
 // assert violation message.
 func DeepEqual(val, want any, a ...any) {
-	if !reflect.DeepEqual(val, want) {
-		defMsg := fmt.Sprintf(assertionMsg+gotWantFmt, val, want)
-		current().reportAssertionFault(0, defMsg, a)
+	if enabled && !reflect.DeepEqual(val, want) {
+		defMsg := gotWantMsg(assertionMsg(), val, want)
+		current().reportGotWant(0, defMsg, val, want, a)
 	}
 }
 
@@ -511,16 +650,62 @@ func DeepEqual(val, want any, a ...any) {
 //
 //	assert.DeepEqual(pubKey, ed25519.PublicKey(pubKeyBytes))
 func NotDeepEqual(val, want any, a ...any) {
-	if reflect.DeepEqual(val, want) {
-		defMsg := fmt.Sprintf(
-			assertionMsg+": got '%v', want (!= '%v')",
-			val,
-			want,
-		)
-		current().reportAssertionFault(0, defMsg, a)
+	if enabled && reflect.DeepEqual(val, want) {
+		f := assertionMsg() + msg(catalog.GotWantNotDeep)
+		defMsg := fmt.Sprintf(f, val, want)
+		current().reportGotWant(0, defMsg, val, want, a)
 	}
 }
 
+// Equaler is the constraint for the types that have the Equal method, e.g.,
+// time.Time. See [EqualMethod].
+type Equaler[T any] interface {
+	Equal(T) bool
+}
+
+// EqualFunc asserts that the values are equal according the eq function. It's
+// for the types that aren't comparable, but it's still type safe unlike
+// [DeepEqual]. If the values aren't equal it panics/errors (according the
+// current [Asserter]) with the auto-generated got-want message, which includes
+// only the differences of the composite values like [DeepEqual] does:
+//
+//	assert.EqualFunc(got, want, func(a, b []byte) bool {
+//	     return bytes.Equal(a, b)
+//	})
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func EqualFunc[T any](val, want T, eq func(a, b T) bool, a ...any) {
+	if enabled && !eq(val, want) {
+		doEqualFunc(val, want, a)
+	}
+}
+
+// EqualMethod asserts that the values are equal according their Equal method,
+// e.g., time.Time values that are the same instant in the different locations
+// are equal. If they aren't equal it panics/errors (according the current
+// [Asserter]) with the auto-generated got-want message like [EqualFunc].
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func EqualMethod[T Equaler[T]](val, want T, a ...any) {
+	if enabled && !val.Equal(want) {
+		doEqualFunc(val, want, a)
+	}
+}
+
+func doEqualFunc[T any](val, want T, a []any) {
+	aname := assertionMsg(catalog.Equal)
+	defMsg := fmt.Sprintf(aname+msg(catalog.GotWant), val, want)
+	// the Stringers like time.Time are more readable as they are
+	if _, ok := any(val).(fmt.Stringer); !ok {
+		defMsg = gotWantMsg(aname, val, want)
+	}
+	current().reportGotWant(1, defMsg, val, want, a)
+}
+
 // Len asserts that the length of the string is equal to the given. If not it
 // panics/errors (according the current [Asserter]) with the auto-generated
 // message. You can append the generated got-want message by using optional
@@ -533,10 +718,13 @@ func NotDeepEqual(val, want any, a ...any) {
 // Note! This is reasonably fast but not as fast as [That] because of lacking
 // inlining for the current implementation of Go's type parametric functions.
 func Len(obj string, length int, a ...any) {
+	if !enabled {
+		return
+	}
 	l := len(obj)
 
 	if l != length {
-		doShouldBeEqual(assertionLenMsg, l, length, a)
+		doShouldBeEqual(catalog.Length, l, length, a)
 	}
 }
 
@@ -552,6 +740,9 @@ func Len(obj string, length int, a ...any) {
 // Note! This is reasonably fast but not as fast as [That] because of lacking
 // inlining for the current implementation of Go's type parametric functions.
 func Longer(s string, length int, a ...any) {
+	if !enabled {
+		return
+	}
 	l := len(s)
 
 	if l <= length {
@@ -560,8 +751,8 @@ func Longer(s string, length int, a ...any) {
 }
 
 func doLonger(l int, length int, a []any) {
-	defMsg := fmt.Sprintf(assertionMsg+gotWantLongerFmt, l, length)
-	current().reportAssertionFault(1, defMsg, a)
+	defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.GotWantLonger), l, length)
+	current().reportGotWant(1, defMsg, l, length, a)
 }
 
 // Shorter asserts that the length of the string is shorter to the given. If not
@@ -576,6 +767,9 @@ func doLonger(l int, length int, a []any) {
 // Note! This is reasonably fast but not as fast as [That] because of lacking
 // inlining for the current implementation of Go's type parametric functions.
 func Shorter(str string, length int, a ...any) {
+	if !enabled {
+		return
+	}
 	l := len(str)
 
 	if l >= length {
@@ -584,10 +778,100 @@ func Shorter(str string, length int, a ...any) {
 }
 
 func doShorter(l int, length int, a []any) {
-	defMsg := fmt.Sprintf(assertionMsg+gotWantShorterFmt, l, length)
+	f := assertionMsg() + msg(catalog.GotWantShorter)
+	defMsg := fmt.Sprintf(f, l, length)
+	current().reportGotWant(1, defMsg, l, length, a)
+}
+
+// Contains asserts that the string contains the substr. If not it
+// panics/errors (according the current [Asserter]) with the auto-generated
+// message. Long strings are truncated in the message.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func Contains(str, substr string, a ...any) {
+	if enabled && !strings.Contains(str, substr) {
+		doStr(catalog.StrNotContains, str, substr, a)
+	}
+}
+
+// HasPrefix asserts that the string begins with the prefix. If not it
+// panics/errors (according the current [Asserter]) with the auto-generated
+// message. Long strings are truncated in the message.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func HasPrefix(str, prefix string, a ...any) {
+	if enabled && !strings.HasPrefix(str, prefix) {
+		doStr(catalog.NoPrefix, str, prefix, a)
+	}
+}
+
+// HasSuffix asserts that the string ends with the suffix. If not it
+// panics/errors (according the current [Asserter]) with the auto-generated
+// message. Long strings are truncated in the message.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func HasSuffix(str, suffix string, a ...any) {
+	if enabled && !strings.HasSuffix(str, suffix) {
+		doStr(catalog.NoSuffix, str, suffix, a)
+	}
+}
+
+// Matches asserts that the string matches the regular expression pattern. If
+// not it panics/errors (according the current [Asserter]) with the
+// auto-generated message. Long strings are truncated in the message. The
+// compiled patterns are cached, so it's OK to call Matches in the hot paths:
+//
+//	assert.Matches(c.PoolName, `^[a-z][a-z0-9-]*$`, "invalid pool name")
+//
+// The invalid pattern is reported as an assertion violation as well.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func Matches(str, pattern string, a ...any) {
+	if !enabled {
+		return
+	}
+	re, err := compile(pattern)
+	if err != nil {
+		doInvalidPattern(pattern, err, a)
+	} else if !re.MatchString(str) {
+		doStr(catalog.NotMatch, str, pattern, a)
+	}
+}
+
+func doStr(id catalog.ID, str, want string, a []any) {
+	got := diff.Truncate(str, diff.MaxValueLen)
+	defMsg := fmt.Sprintf(assertionMsg()+msg(id), got, want)
+	current().reportGotWant(1, defMsg, str, want, a)
+}
+
+func doInvalidPattern(pattern string, err error, a []any) {
+	f := assertionMsg() + msg(catalog.InvalidPattern)
+	defMsg := fmt.Sprintf(f, pattern, err)
 	current().reportAssertionFault(1, defMsg, a)
 }
 
+// compile returns the compiled regexp of the pattern from the cache, or
+// compiles and caches it.
+func compile(pattern string) (re *regexp.Regexp, err error) {
+	if re = regexps.Get(pattern); re != nil {
+		return re, nil
+	}
+	re, err = regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regexps.Set(pattern, re)
+	return re, nil
+}
+
 // SLen asserts that the length of the slice is equal to the given. If not it
 // panics/errors (according the current [Asserter]) with the auto-generated
 // message. You can append the generated got-want message by using optional
@@ -600,10 +884,13 @@ func doShorter(l int, length int, a []any) {
 // Note! This is reasonably fast but not as fast as [That] because of lacking
 // inlining for the current implementation of Go's type parametric functions.
 func SLen[S ~[]T, T any](obj S, length int, a ...any) {
+	if !enabled {
+		return
+	}
 	l := len(obj)
 
 	if l != length {
-		doShouldBeEqual(assertionLenMsg, l, length, a)
+		doShouldBeEqual(catalog.Length, l, length, a)
 	}
 }
 
@@ -619,6 +906,9 @@ func SLen[S ~[]T, T any](obj S, length int, a ...any) {
 // Note! This is reasonably fast but not as fast as [That] because of lacking
 // inlining for the current implementation of Go's type parametric functions.
 func SLonger[S ~[]T, T any](obj S, length int, a ...any) {
+	if !enabled {
+		return
+	}
 	l := len(obj)
 
 	if l <= length {
@@ -638,6 +928,9 @@ func SLonger[S ~[]T, T any](obj S, length int, a ...any) {
 // Note! This is reasonably fast but not as fast as [That] because of lacking
 // inlining for the current implementation of Go's type parametric functions.
 func SShorter[S ~[]T, T any](obj S, length int, a ...any) {
+	if !enabled {
+		return
+	}
 	l := len(obj)
 
 	if l >= length {
@@ -657,10 +950,13 @@ func SShorter[S ~[]T, T any](obj S, length int, a ...any) {
 // Note! This is reasonably fast but not as fast as [That] because of lacking
 // inlining for the current implementation of Go's type parametric functions.
 func MLen[M ~map[T]U, T comparable, U any](obj M, length int, a ...any) {
+	if !enabled {
+		return
+	}
 	l := len(obj)
 
 	if l != length {
-		doShouldBeEqual(assertionLenMsg, l, length, a)
+		doShouldBeEqual(catalog.Length, l, length, a)
 	}
 }
 
@@ -676,6 +972,9 @@ func MLen[M ~map[T]U, T comparable, U any](obj M, length int, a ...any) {
 // Note! This is reasonably fast but not as fast as [That] because of lacking
 // inlining for the current implementation of Go's type parametric functions.
 func MLonger[M ~map[T]U, T comparable, U any](obj M, length int, a ...any) {
+	if !enabled {
+		return
+	}
 	l := len(obj)
 
 	if l <= length {
@@ -695,6 +994,9 @@ func MLonger[M ~map[T]U, T comparable, U any](obj M, length int, a ...any) {
 // Note! This is reasonably fast but not as fast as [That] because of lacking
 // inlining for the current implementation of Go's type parametric functions.
 func MShorter[M ~map[T]U, T comparable, U any](obj M, length int, a ...any) {
+	if !enabled {
+		return
+	}
 	l := len(obj)
 
 	if l >= length {
@@ -714,10 +1016,13 @@ func MShorter[M ~map[T]U, T comparable, U any](obj M, length int, a ...any) {
 // Note! This is reasonably fast but not as fast as [That] because of lacking
 // inlining for the current implementation of Go's type parametric functions.
 func CLen[C ~chan T, T any](obj C, length int, a ...any) {
+	if !enabled {
+		return
+	}
 	l := len(obj)
 
 	if l != length {
-		doShouldBeEqual(assertionLenMsg, l, length, a)
+		doShouldBeEqual(catalog.Length, l, length, a)
 	}
 }
 
@@ -733,6 +1038,9 @@ func CLen[C ~chan T, T any](obj C, length int, a ...any) {
 // Note! This is reasonably fast but not as fast as [That] because of lacking
 // inlining for the current implementation of Go's type parametric functions.
 func CLonger[C ~chan T, T any](obj C, length int, a ...any) {
+	if !enabled {
+		return
+	}
 	l := len(obj)
 
 	if l <= length {
@@ -752,6 +1060,9 @@ func CLonger[C ~chan T, T any](obj C, length int, a ...any) {
 // Note! This is reasonably fast but not as fast as [That] because of lacking
 // inlining for the current implementation of Go's type parametric functions.
 func CShorter[C ~chan T, T any](obj C, length int, a ...any) {
+	if !enabled {
+		return
+	}
 	l := len(obj)
 
 	if l >= length {
@@ -770,6 +1081,9 @@ func MKeyExists[M ~map[T]U, T comparable, U any](
 	key T,
 	a ...any,
 ) (val U) {
+	if !enabled {
+		return obj[key]
+	}
 	var ok bool
 	val, ok = obj[key]
 
@@ -780,10 +1094,250 @@ func MKeyExists[M ~map[T]U, T comparable, U any](
 }
 
 func doMKeyExists(key any, a []any) {
-	defMsg := fmt.Sprintf(assertionMsg+": key '%v' doesn't exist", key)
+	defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.KeyNotExist), key)
+	current().reportAssertionFault(1, defMsg, a)
+}
+
+// MKeyNotExists asserts that the map key doesn't exist. If it does it
+// panics/errors (current [Asserter]) the auto-generated (args appended)
+// message.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func MKeyNotExists[M ~map[T]U, T comparable, U any](obj M, key T, a ...any) {
+	if !enabled {
+		return
+	}
+	if _, ok := obj[key]; ok {
+		doMKeyNotExists(key, a)
+	}
+}
+
+func doMKeyNotExists(key any, a []any) {
+	defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.KeyExists), key)
+	current().reportAssertionFault(1, defMsg, a)
+}
+
+// MValueEquals asserts that the map key exists and its value is equal to want.
+// If not it panics/errors (current [Asserter]) the auto-generated (args
+// appended) message.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func MValueEquals[M ~map[T]U, T, U comparable](obj M, key T, want U, a ...any) {
+	if !enabled {
+		return
+	}
+	val, ok := obj[key]
+	if !ok {
+		doMKeyExists(key, a)
+	} else if val != want {
+		doMValueEquals(key, val, want, a)
+	}
+}
+
+func doMValueEquals(key, val, want any, a []any) {
+	f := assertionMsg() + msg(catalog.ValueNotEqual)
+	defMsg := fmt.Sprintf(f, key, val, want)
+	current().reportGotWant(1, defMsg, val, want, a)
+}
+
+// SContains asserts that the slice contains the element. If not it
+// panics/errors (according the current [Asserter]) with the auto-generated
+// message. You can append the generated message by using optional message
+// arguments.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func SContains[S ~[]T, T comparable](obj S, elem T, a ...any) {
+	if enabled && index(obj, elem) == -1 {
+		doSContains(elem, a)
+	}
+}
+
+func doSContains(elem any, a []any) {
+	defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.SliceNotContains), elem)
+	current().reportAssertionFault(1, defMsg, a)
+}
+
+// SNotContains asserts that the slice doesn't contain the element. If it does
+// it panics/errors (according the current [Asserter]) with the auto-generated
+// message that includes the index of the element.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func SNotContains[S ~[]T, T comparable](obj S, elem T, a ...any) {
+	if !enabled {
+		return
+	}
+	if i := index(obj, elem); i != -1 {
+		doSNotContains(elem, i, a)
+	}
+}
+
+func doSNotContains(elem any, i int, a []any) {
+	defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.SliceContains), elem, i)
+	current().reportAssertionFault(1, defMsg, a)
+}
+
+// SSubset asserts that the slice contains all the elements of the sub slice.
+// If not it panics/errors (according the current [Asserter]) with the
+// auto-generated message that tells the first missing element.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func SSubset[S ~[]T, T comparable](obj, sub S, a ...any) {
+	if !enabled {
+		return
+	}
+	for i, elem := range sub {
+		if index(obj, elem) == -1 {
+			doSSubset(elem, i, a)
+			return
+		}
+	}
+}
+
+func doSSubset(elem any, i int, a []any) {
+	defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.NotSubset), elem, i)
+	current().reportAssertionFault(1, defMsg, a)
+}
+
+// SElementsMatch asserts that the slices have the same elements regardless of
+// their order. The duplicates must match as well. If not it panics/errors
+// (according the current [Asserter]) with the auto-generated message that
+// includes the extra and the missing elements.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func SElementsMatch[S ~[]T, T comparable](obj, want S, a ...any) {
+	if !enabled {
+		return
+	}
+	extra, missing := elementsDiff(obj, want)
+	if extra != nil || missing != nil {
+		doSElementsMatch(extra, missing, a)
+	}
+}
+
+func doSElementsMatch(extra, missing any, a []any) {
+	f := assertionMsg() + msg(catalog.ElementsNotMatch)
+	defMsg := fmt.Sprintf(f, extra, missing)
+	current().reportAssertionFault(1, defMsg, a)
+}
+
+// elementsDiff returns the elements of the obj that are not in want (extra),
+// and the elements of want that are not in obj (missing). Both are nil if the
+// slices have the same elements.
+func elementsDiff[S ~[]T, T comparable](obj, want S) (extra, missing []T) {
+	counts := make(map[T]int, len(want))
+	for _, elem := range want {
+		counts[elem]++
+	}
+	for _, elem := range obj {
+		if counts[elem] > 0 {
+			counts[elem]--
+		} else {
+			extra = append(extra, elem)
+		}
+	}
+	for _, elem := range want {
+		if counts[elem] > 0 {
+			counts[elem]--
+			missing = append(missing, elem)
+		}
+	}
+	return extra, missing
+}
+
+// SSorted asserts that the slice is sorted in ascending order. If not it
+// panics/errors (according the current [Asserter]) with the auto-generated
+// message that tells the first unsorted index. Use [SSortedFunc] for custom
+// ordering.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func SSorted[S ~[]T, T constraints.Ordered](obj S, a ...any) {
+	if !enabled {
+		return
+	}
+	for i := 1; i < len(obj); i++ {
+		if obj[i] < obj[i-1] {
+			doSSorted(i, obj[i], obj[i-1], a)
+			return
+		}
+	}
+}
+
+// SSortedFunc asserts that the slice is sorted in ascending order according
+// the less function. If not it panics/errors (according the current
+// [Asserter]) with the auto-generated message that tells the first unsorted
+// index.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func SSortedFunc[S ~[]T, T any](obj S, less func(a, b T) bool, a ...any) {
+	if !enabled {
+		return
+	}
+	for i := 1; i < len(obj); i++ {
+		if less(obj[i], obj[i-1]) {
+			doSSorted(i, obj[i], obj[i-1], a)
+			return
+		}
+	}
+}
+
+func doSSorted(i int, val, prev any, a []any) {
+	defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.NotSorted), i, val, prev)
+	current().reportAssertionFault(1, defMsg, a)
+}
+
+// SUnique asserts that the slice doesn't have duplicate elements. If it has
+// it panics/errors (according the current [Asserter]) with the auto-generated
+// message that tells the indexes of the first duplicate.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func SUnique[S ~[]T, T comparable](obj S, a ...any) {
+	if !enabled {
+		return
+	}
+	seen := make(map[T]int, len(obj))
+	for i, elem := range obj {
+		if j, found := seen[elem]; found {
+			doSUnique(elem, j, i, a)
+			return
+		}
+		seen[elem] = i
+	}
+}
+
+func doSUnique(elem any, i, j int, a []any) {
+	defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.NotUnique), elem, i, j)
 	current().reportAssertionFault(1, defMsg, a)
 }
 
+// index returns the index of the first occurrence of the elem, or -1 if it
+// isn't found.
+func index[S ~[]T, T comparable](obj S, elem T) int {
+	for i := range obj {
+		if obj[i] == elem {
+			return i
+		}
+	}
+	return -1
+}
+
 // NotEmpty asserts that the string is not empty. If it is, it panics/errors
 // (according the current [Asserter]) with the auto-generated message. You can
 // append the generated got-want message by using optional message arguments.
@@ -792,8 +1346,8 @@ func doMKeyExists(key any, a []any) {
 // [SetDefault]), optional arguments are used to override the auto-generated
 // assert violation message.
 func NotEmpty(obj string, a ...any) {
-	if obj == "" {
-		doNamed("not", "string", "empty", a)
+	if enabled && obj == "" {
+		doNamed("not", catalog.TypeString, catalog.Empty, a)
 	}
 }
 
@@ -805,8 +1359,8 @@ func NotEmpty(obj string, a ...any) {
 // [SetDefault]), optional arguments are used to override the auto-generated
 // assert violation message.
 func Empty(obj string, a ...any) {
-	if obj != "" {
-		doNamed("", "string", "empty", a)
+	if enabled && obj != "" {
+		doNamed("", catalog.TypeString, catalog.Empty, a)
 	}
 }
 
@@ -821,10 +1375,13 @@ func Empty(obj string, a ...any) {
 // Note! This is reasonably fast but not as fast as [That] because of lacking
 // inlining for the current implementation of Go's type parametric functions.
 func SEmpty[S ~[]T, T any](obj S, a ...any) {
+	if !enabled {
+		return
+	}
 	l := len(obj)
 
 	if l != 0 {
-		doNamed("", "slice", "empty", a)
+		doNamed("", catalog.TypeSlice, catalog.Empty, a)
 	}
 }
 
@@ -839,10 +1396,13 @@ func SEmpty[S ~[]T, T any](obj S, a ...any) {
 // Note! This is reasonably fast but not as fast as [That] because of lacking
 // inlining for the current implementation of Go's type parametric functions.
 func SNotEmpty[S ~[]T, T any](obj S, a ...any) {
+	if !enabled {
+		return
+	}
 	l := len(obj)
 
 	if l == 0 {
-		doNamed("not", "slice", "empty", a)
+		doNamed("not", catalog.TypeSlice, catalog.Empty, a)
 	}
 }
 
@@ -859,10 +1419,13 @@ func SNotEmpty[S ~[]T, T any](obj S, a ...any) {
 // Note! This is reasonably fast but not as fast as [That] because of lacking
 // inlining for the current implementation of Go's type parametric functions.
 func MEmpty[M ~map[T]U, T comparable, U any](obj M, a ...any) {
+	if !enabled {
+		return
+	}
 	l := len(obj)
 
 	if l != 0 {
-		doNamed("", "map", "empty", a)
+		doNamed("", catalog.TypeMap, catalog.Empty, a)
 	}
 }
 
@@ -879,16 +1442,20 @@ func MEmpty[M ~map[T]U, T comparable, U any](obj M, a ...any) {
 // Note! This is reasonably fast but not as fast as [That] because of lacking
 // inlining for the current implementation of Go's type parametric functions.
 func MNotEmpty[M ~map[T]U, T comparable, U any](obj M, a ...any) {
+	if !enabled {
+		return
+	}
 	l := len(obj)
 
 	if l == 0 {
-		doNamed("not", "map", "empty", a)
+		doNamed("not", catalog.TypeMap, catalog.Empty, a)
 	}
 }
 
-func doNamed(not, tname, got string, a []any) {
-	not = x.Whom(not == assertionNot, " not ", " ")
-	defMsg := assertionMsg + ": " + tname + " should" + not + "be " + got
+func doNamed(not string, tname, got catalog.ID, a []any) {
+	f := msg(x.Whom(not == assertionNot, catalog.ShouldNotBe, catalog.ShouldBe))
+	defMsg := assertionMsg() + conCatErrStr +
+		fmt.Sprintf(f, msg(tname), msg(got))
 	current().reportAssertionFault(1, defMsg, a)
 }
 
@@ -903,8 +1470,8 @@ func doNamed(not, tname, got string, a []any) {
 // wanted at runtime. With asserts ([Production], [Development], [Debug]) you
 // get the file location as well.
 func NoError(err error, a ...any) {
-	if err != nil {
-		defMsg := assertionMsg + conCatErrStr + err.Error()
+	if enabled && err != nil {
+		defMsg := assertionMsg() + conCatErrStr + err.Error()
 		current().reportAssertionFault(0, defMsg, a)
 	}
 }
@@ -917,16 +1484,211 @@ func NoError(err error, a ...any) {
 // [SetDefault]), optional arguments are used to override the auto-generated
 // assert violation message.
 func Error(err error, a ...any) {
-	if err == nil {
+	if enabled && err == nil {
 		doError(a)
 	}
 }
 
 func doError(a []any) {
-	defMsg := "Error:" + assertionMsg + ": missing error"
+	defMsg := "Error:" + assertionMsg(catalog.MissingError)
+	current().reportAssertionFault(1, defMsg, a)
+}
+
+// ErrorIs asserts that the err's chain includes the target error, i.e.,
+// [errors.Is] returns true. If not it panics/errors (according the current
+// [Asserter]) with the auto-generated message that includes the whole error
+// chain. It's handy to check that err2.Handle keeps the sentinel errors
+// wrapped:
+//
+//	assert.ErrorIs(err, err2.ErrNotFound)
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func ErrorIs(err, target error, a ...any) {
+	if enabled && !errors.Is(err, target) {
+		doErrorIs(err, target, a)
+	}
+}
+
+func doErrorIs(err, target error, a []any) {
+	defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.ErrorIsNot), target) +
+		errChain(err)
 	current().reportAssertionFault(1, defMsg, a)
 }
 
+// ErrorAs asserts that the err's chain includes an error that matches to type
+// T, i.e., [errors.As] returns true. It returns the matched value. If there
+// isn't match it panics/errors (according the current [Asserter]) with the
+// auto-generated message that includes the whole error chain:
+//
+//	pathErr := assert.ErrorAs[*fs.PathError](err)
+//
+// Note that T must be an interface or implement error. See [errors.As].
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func ErrorAs[T any](err error, a ...any) (val T) {
+	if !enabled {
+		errors.As(err, &val)
+		return val
+	}
+	if !errors.As(err, &val) {
+		doErrorAs(err, reflect.TypeOf(&val).Elem(), a)
+	}
+	return val
+}
+
+func doErrorAs(err error, typ reflect.Type, a []any) {
+	defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.ErrorAsNot), typ) +
+		errChain(err)
+	current().reportAssertionFault(1, defMsg, a)
+}
+
+// ErrorContains asserts that the err is not nil and its message contains the
+// substr. If not it panics/errors (according the current [Asserter]) with the
+// auto-generated message that includes the whole error chain.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func ErrorContains(err error, substr string, a ...any) {
+	if !enabled {
+		return
+	}
+	if err == nil {
+		doError(a)
+	} else if !strings.Contains(err.Error(), substr) {
+		doErrorContains(err, substr, a)
+	}
+}
+
+func doErrorContains(err error, substr string, a []any) {
+	f := assertionMsg() + msg(catalog.ErrorNotContains)
+	defMsg := fmt.Sprintf(f, err.Error(), substr) + errChain(err)
+	current().reportAssertionFault(1, defMsg, a)
+}
+
+// errChain returns the whole error chain as a string, every error in its own
+// line. The multi-wrapped errors (trees) are indented by their depth.
+func errChain(err error) string {
+	if err == nil {
+		return "\n" + msg(catalog.ErrorChain) + " <nil>"
+	}
+	var b strings.Builder
+	b.WriteString("\n" + msg(catalog.ErrorChain))
+	x.WalkErrors(err, func(e error, depth int) bool {
+		fmt.Fprintf(&b, "\n%s%T: %q",
+			strings.Repeat("  ", depth+1), e, e.Error())
+		return true
+	})
+	return b.String()
+}
+
+// Panics asserts that the function f panics. If it doesn't it panics/errors
+// (according the current [Asserter]) with the auto-generated message. It's
+// handy to test functions that use [github.com/lainio/err2/try.To] or
+// err2.Throwf without err2.Handle:
+//
+//	assert.Panics(func() { try.To(os.Remove("not-exist")) })
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func Panics(f func(), a ...any) {
+	if !enabled {
+		return
+	}
+	if _, panicked, _ := catchPanic(f); !panicked {
+		doPanics(a)
+	}
+}
+
+func doPanics(a []any) {
+	defMsg := assertionMsg() + msg(catalog.NoPanic)
+	current().reportAssertionFault(1, defMsg, a)
+}
+
+// NotPanics asserts that the function f doesn't panic. If it does it
+// panics/errors (according the current [Asserter]) with the auto-generated
+// message that includes the recovered panic value. With [TestFull] and
+// [Debug] the message includes the call stack of the panic as well.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func NotPanics(f func(), a ...any) {
+	if !enabled {
+		return
+	}
+	if r, panicked, stack := catchPanic(f); panicked {
+		doNotPanics(r, stack, a)
+	}
+}
+
+func doNotPanics(r any, stack []byte, a []any) {
+	asserter := current()
+	defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.Panic), r)
+	asserter.reportAssertionFault(1, asserter.panicStack(defMsg, stack), a)
+}
+
+// PanicsWith asserts that the function f panics and the recovered value
+// matches to the match predicate. If not it panics/errors (according the
+// current [Asserter]) with the auto-generated message. The recovered value is
+// typically an error, e.g.:
+//
+//	assert.PanicsWith(func() { try.To(f()) }, func(r any) bool {
+//		err, ok := r.(error)
+//		return ok && errors.Is(err, err2.ErrNotFound)
+//	})
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func PanicsWith(f func(), match func(r any) bool, a ...any) {
+	if !enabled {
+		return
+	}
+	r, panicked, stack := catchPanic(f)
+	if !panicked {
+		doPanics(a)
+	} else if !match(r) {
+		doPanicsWith(r, stack, a)
+	}
+}
+
+func doPanicsWith(r any, stack []byte, a []any) {
+	asserter := current()
+	defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.PanicNotMatch), r)
+	asserter.reportAssertionFault(1, asserter.panicStack(defMsg, stack), a)
+}
+
+// catchPanic calls f and returns the recovered value and the call stack of the
+// panic if f panicked. The panicked flag is needed because the recovered value
+// can be nil.
+func catchPanic(f func()) (r any, panicked bool, stack []byte) {
+	panicked = true
+	defer func() {
+		if panicked {
+			r = recover()
+			stack = rdebug.Stack()
+		}
+	}()
+	f()
+	return nil, false, nil
+}
+
+// panicStack appends the call stack of the recovered panic to the message if
+// the asserter prints the call stacks (Debug panics with them), i.e., the
+// panic's origin isn't lost.
+func (asserter asserter) panicStack(s string, stack []byte) string {
+	if !asserter.hasStackTrace() && asserter != asserterDebug {
+		return s
+	}
+	return s + "\n" + msg(catalog.PanicStack) + "\n" + string(stack)
+}
+
 // Greater asserts that the value is greater than want. If it is not it panics
 // and builds a violation message. Thanks to inlining, the performance penalty
 // is equal to a single 'if-statement' that is almost nothing.
@@ -935,14 +1697,14 @@ func doError(a []any) {
 // [SetDefault]), optional arguments are used to override the auto-generated
 // assert violation message.
 func Greater[T Number](val, want T, a ...any) {
-	if val <= want {
+	if enabled && val <= want {
 		doGreater(val, want, a)
 	}
 }
 
 func doGreater[T Number](val, want T, a []any) {
-	defMsg := fmt.Sprintf(assertionMsg+": got '%v', want <= '%v'", val, want)
-	current().reportAssertionFault(1, defMsg, a)
+	defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.GotWantGreater), val, want)
+	current().reportGotWant(1, defMsg, val, want, a)
 }
 
 // Less asserts that the value is less than want. If it is not it panics and
@@ -953,14 +1715,14 @@ func doGreater[T Number](val, want T, a []any) {
 // [SetDefault]), optional arguments are used to override the auto-generated
 // assert violation message.
 func Less[T Number](val, want T, a ...any) {
-	if val >= want {
+	if enabled && val >= want {
 		doLess(val, want, a)
 	}
 }
 
 func doLess[T Number](val, want T, a []any) {
-	defMsg := fmt.Sprintf(assertionMsg+": got '%v', want >= '%v'", val, want)
-	current().reportAssertionFault(1, defMsg, a)
+	defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.GotWantLess), val, want)
+	current().reportGotWant(1, defMsg, val, want, a)
 }
 
 // Zero asserts that the value is 0. If it is not it panics and builds a
@@ -971,14 +1733,14 @@ func doLess[T Number](val, want T, a []any) {
 // [SetDefault]), optional arguments are used to override the auto-generated
 // assert violation message.
 func Zero[T Number](val T, a ...any) {
-	if val != 0 {
+	if enabled && val != 0 {
 		doZero(val, a)
 	}
 }
 
 func doZero[T Number](val T, a []any) {
-	defMsg := fmt.Sprintf(assertionMsg+": got '%v', want (== '0')", val)
-	current().reportAssertionFault(1, defMsg, a)
+	defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.GotWantZero), val)
+	current().reportGotWant(1, defMsg, val, T(0), a)
 }
 
 // NotZero asserts that the value != 0. If it is not it panics and builds a
@@ -989,16 +1751,229 @@ func doZero[T Number](val T, a []any) {
 // [SetDefault]), optional arguments are used to override the auto-generated
 // assert violation message.
 func NotZero[T Number](val T, a ...any) {
-	if val == 0 {
+	if enabled && val == 0 {
 		doNotZero(val, a)
 	}
 }
 
 func doNotZero[T Number](val T, a []any) {
-	defMsg := fmt.Sprintf(assertionMsg+": got '%v', want (!= 0)", val)
+	defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.GotWantNotZero), val)
+	current().reportGotWant(1, defMsg, val, T(0), a)
+}
+
+// InDelta asserts that the value is within the delta from want, i.e.,
+// |val-want| <= delta. If not it panics/errors (according the current
+// [Asserter]) with the auto-generated message that includes the difference.
+//
+// NaN values are never within the delta, and infinite values are within it
+// only if they are equal to want. Both cases have their own messages. The
+// negative delta is reported as an invalid tolerance.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func InDelta[T Number](val, want, delta T, a ...any) {
+	if enabled && !inDelta(val, want, delta) {
+		doInDelta(val, want, delta, a)
+	}
+}
+
+func inDelta[T Number](val, want, delta T) bool {
+	if isNaN(val) || isNaN(want) || isNaN(delta) || delta < 0 {
+		return false
+	}
+	if isInf(val) || isInf(want) {
+		return val == want
+	}
+	if isFloat[T]() {
+		return absDiff(val, want) <= delta
+	}
+	return intDist(val, want) <= uint64(delta)
+}
+
+// diffOf returns the difference of the values for the messages without the
+// integer overflows.
+func diffOf[T Number](a, b T) any {
+	if isFloat[T]() {
+		return absDiff(a, b)
+	}
+	return intDist(a, b)
+}
+
+func doInDelta[T Number](val, want, delta T, a []any) {
+	var defMsg string
+	switch {
+	case isNaN(val) || isNaN(want) || isNaN(delta):
+		defMsg = fmt.Sprintf(assertionMsg()+msg(catalog.GotWantNaN), val, want)
+	case delta < 0:
+		defMsg = fmt.Sprintf(assertionMsg()+msg(catalog.InvalidTolerance), delta)
+	case isInf(val) || isInf(want):
+		defMsg = fmt.Sprintf(assertionMsg()+msg(catalog.GotWantInf), val, want)
+	default:
+		f := assertionMsg() + msg(catalog.GotWantDelta)
+		defMsg = fmt.Sprintf(f, val, want, delta, diffOf(val, want))
+	}
+	current().reportGotWant(1, defMsg, val, want, a)
+}
+
+// InEpsilon asserts that the relative error between the value and want is
+// within the epsilon, i.e., |val-want|/|want| <= epsilon. If not it
+// panics/errors (according the current [Asserter]) with the auto-generated
+// message that includes the relative error.
+//
+// NaN values are never within the epsilon, and infinite values are within it
+// only if they are equal to want. The relative error is undefined when want
+// is zero, and then val must be zero as well. All of these cases have their own
+// messages. The negative epsilon is reported as an invalid tolerance.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func InEpsilon[T Number](val, want T, epsilon float64, a ...any) {
+	if enabled && !inEpsilon(float64(val), float64(want), epsilon) {
+		doInEpsilon(val, want, epsilon, a)
+	}
+}
+
+func inEpsilon(val, want, epsilon float64) bool {
+	if val == want { // equal infinities as well, NaN is never equal
+		return epsilon >= 0
+	}
+	if math.IsInf(val, 0) || math.IsInf(want, 0) {
+		return false
+	}
+	return math.Abs(val-want)/math.Abs(want) <= epsilon
+}
+
+func doInEpsilon[T Number](val, want T, epsilon float64, a []any) {
+	var defMsg string
+	switch {
+	case isNaN(val) || isNaN(want) || isNaN(epsilon):
+		defMsg = fmt.Sprintf(assertionMsg()+msg(catalog.GotWantNaN), val, want)
+	case epsilon < 0:
+		f := assertionMsg() + msg(catalog.InvalidTolerance)
+		defMsg = fmt.Sprintf(f, epsilon)
+	case isInf(val) || isInf(want):
+		defMsg = fmt.Sprintf(assertionMsg()+msg(catalog.GotWantInf), val, want)
+	case want == 0:
+		defMsg = fmt.Sprintf(assertionMsg()+msg(catalog.GotWantRelZero), val)
+	default:
+		v, w := float64(val), float64(want)
+		relErr := math.Abs(v-w) / math.Abs(w)
+		f := assertionMsg() + msg(catalog.GotWantEpsilon)
+		defMsg = fmt.Sprintf(f, val, want, epsilon, relErr)
+	}
+	current().reportGotWant(1, defMsg, val, want, a)
+}
+
+// Between asserts that the value is between lo and hi inclusive, i.e.,
+// lo <= val <= hi. If not it panics/errors (according the current [Asserter])
+// with the auto-generated message. NaN is never between any values, and it has
+// its own message. The infinite bounds are allowed.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func Between[T Number](val, lo, hi T, a ...any) {
+	if enabled && !(lo <= val && val <= hi) {
+		doBetween(catalog.GotWantBetween, val, lo, hi, a)
+	}
+}
+
+// BetweenExclusive asserts that the value is between lo and hi exclusive,
+// i.e., lo < val < hi. If not it panics/errors (according the current
+// [Asserter]) with the auto-generated message. NaN is never between any
+// values, and it has its own message. The infinite bounds are allowed.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func BetweenExclusive[T Number](val, lo, hi T, a ...any) {
+	if enabled && !(lo < val && val < hi) {
+		doBetween(catalog.GotWantBetweenExclusive, val, lo, hi, a)
+	}
+}
+
+func doBetween[T Number](id catalog.ID, val, lo, hi T, a []any) {
+	var defMsg string
+	if isNaN(val) || isNaN(lo) || isNaN(hi) {
+		want := fmt.Sprintf("%v..%v", lo, hi)
+		defMsg = fmt.Sprintf(assertionMsg()+msg(catalog.GotWantNaN), val, want)
+	} else {
+		defMsg = fmt.Sprintf(assertionMsg()+msg(id), val, lo, hi)
+	}
+	current().reportAssertionFault(1, defMsg, a)
+}
+
+// Positive asserts that the value is greater than zero. If not it
+// panics/errors (according the current [Asserter]) with the auto-generated
+// message. NaN isn't positive, and it has its own message.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func Positive[T Number](val T, a ...any) {
+	if enabled && !(val > 0) {
+		doSign(catalog.GotWantPositive, val, "> 0", a)
+	}
+}
+
+// Negative asserts that the value is less than zero. If not it panics/errors
+// (according the current [Asserter]) with the auto-generated message. NaN
+// isn't negative, and it has its own message.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func Negative[T Number](val T, a ...any) {
+	if enabled && !(val < 0) {
+		doSign(catalog.GotWantNegative, val, "< 0", a)
+	}
+}
+
+func doSign[T Number](id catalog.ID, val T, want string, a []any) {
+	var defMsg string
+	if isNaN(val) {
+		defMsg = fmt.Sprintf(assertionMsg()+msg(catalog.GotWantNaN), val, want)
+	} else {
+		defMsg = fmt.Sprintf(assertionMsg()+msg(id), val)
+	}
 	current().reportAssertionFault(1, defMsg, a)
 }
 
+// absDiff returns |a-b|. It works with unsigned integers as well.
+func absDiff[T Number](a, b T) T {
+	if a < b {
+		return b - a
+	}
+	return a - b
+}
+
+// intDist returns the distance of the integers as uint64, which cannot
+// overflow: the conversion sign-extends the signed values, and the wrapping
+// subtraction gives the exact distance.
+func intDist[T Number](a, b T) uint64 {
+	if a < b {
+		a, b = b, a
+	}
+	return uint64(a) - uint64(b)
+}
+
+// isFloat returns true if T is a floating-point type.
+func isFloat[T Number]() bool {
+	var half T = 1
+	half /= 2
+	return half != 0
+}
+
+func isNaN[T Number](v T) bool {
+	return v != v
+}
+
+func isInf[T Number](v T) bool {
+	return math.IsInf(float64(v), 0)
+}
+
 // current returns a current default [Asserter] used for assert functions like
 // assert.That() in this gorounine.
 //
@@ -1007,7 +1982,13 @@ func doNotZero[T Number](val T, a []any) {
 // locks HERE. Only the setting the index is secured with the mutex.
 //
 // NOTE that since our GLS [asserterMap] we still continue to use indexing.
+//
+// NOTE that goid() is expensive, so it's called only if some goroutine has its
+// own asserter.
 func current() (curAsserter asserter) {
+	if asserterMap.Len() == 0 {
+		return asserterOf(def) // fast path: no GLS asserters at all
+	}
 	glsID := goid()
 	asserterMap.Rx(func(m map[int]asserter) {
 		aster, found := m[glsID]
@@ -1015,12 +1996,24 @@ func current() (curAsserter asserter) {
 			curAsserter = aster
 		} else {
 			// use pkg lvl asserter if asserter is not set for gorounine.
-			curAsserter = defAsserter[def]
+			curAsserter = asserterOf(def)
 		}
 	})
 	return curAsserter
 }
 
+// hasGLSAsserter returns true if the current goroutine has its own asserter.
+func hasGLSAsserter() (found bool) {
+	if asserterMap.Len() == 0 {
+		return false
+	}
+	glsID := goid()
+	asserterMap.Rx(func(m map[int]asserter) {
+		_, found = m[glsID]
+	})
+	return found
+}
+
 // SetDefault sets the new default [Asserter] for the assert pkg instance you're
 // currently using. It also returns the previous [Asserter]. The default
 // asserter is [Production] that's best for most use cases and packages.
@@ -1032,7 +2025,12 @@ func current() (curAsserter asserter) {
 //
 // Note that if you are using tracers you might get overlapping call stacks, so
 // test what's best for your case.
+//
+// SetDefault panics if i isn't a built-in or a registered [Asserter], see
+// [Register].
 func SetDefault(i Asserter) (old Asserter) {
+	mustBeAsserter(i)
+
 	// pkg lvl lock to allow only one pkg client call this at one of the time
 	// together with the indexing, i.e we don't need to switch asserter
 	// variable or pointer to it but just index to array they are stored.
@@ -1049,6 +2047,102 @@ func SetDefault(i Asserter) (old Asserter) {
 	return
 }
 
+// SetPackageDefault sets the default [Asserter] for the package of the import
+// path pkgPath, i.e., it overrides [SetDefault] for the assertions that are
+// called from the package. It returns the previous package default, or the
+// current default [Asserter] if there wasn't any. The pkgPath ending with
+// "/..." matches the sub-packages as well:
+//
+//	assert.SetDefault(assert.Development) // our app
+//	assert.SetPackageDefault("github.com/some/lib/...", assert.Production)
+//
+// Note that the goroutine specific [Asserter] set by [PushAsserter] still
+// overrides the package defaults, and so does the unit testing mode
+// ([PushTester], [UseTester]), i.e., the violations fail the running test. See
+// [ResetPackageDefault].
+//
+// Note that the package default only decides how the violations of the
+// package are reported. The other decisions are still made according the
+// default [Asserter], e.g., if we are in the unit testing mode ([PushTester],
+// [PushAsserter]) or if the contracts are on ([SetContracts]).
+//
+// SetPackageDefault panics if i isn't a built-in or a registered [Asserter].
+func SetPackageDefault(pkgPath string, i Asserter) (old Asserter) {
+	mustBeAsserter(i)
+	mu.Lock()
+	old = def
+	mu.Unlock()
+	pkgDefaults.Tx(func(m pkgAsserterMap) {
+		if prev, found := m[pkgPath]; found {
+			old = prev
+		}
+		m[pkgPath] = i
+	})
+	return old
+}
+
+// ResetPackageDefault removes the package default [Asserter] that is set by
+// [SetPackageDefault], i.e., the package uses the default [Asserter] again.
+func ResetPackageDefault(pkgPath string) {
+	pkgDefaults.Del(pkgPath)
+}
+
+// packageDefault returns the package default asserter for the function if
+// it's set. The function is the full name of it, e.g.,
+// 'github.com/some/lib/pkg.(*Type).Method'.
+func packageDefault(function string) (a asserter, found bool) {
+	if pkgDefaults.Len() == 0 || function == "" {
+		return a, false // fast path: no package defaults
+	}
+	pkg := funcPackage(function)
+	pkgDefaults.Rx(func(m pkgAsserterMap) {
+		var i Asserter
+		if i, found = m[pkg]; found {
+			a = asserterOf(i)
+			return
+		}
+		for p := pkg; p != "." && p != "/"; p = path.Dir(p) {
+			if i, found = m[p+"/..."]; found {
+				a = asserterOf(i)
+				return
+			}
+		}
+	})
+	return a, found
+}
+
+// funcPackage returns the import path of the function's package, e.g.,
+// 'github.com/some/lib/pkg.(*Type).Method' -> 'github.com/some/lib/pkg'. The
+// runtime escapes the dots of the last path element, e.g., 'gopkg.in/yaml%2ev3',
+// which are unescaped.
+func funcPackage(function string) string {
+	slash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[slash+1:], "."); dot != -1 {
+		function = function[:slash+1+dot]
+	}
+	return unescapePath(function)
+}
+
+// unescapePath decodes the %xx escapes that the runtime uses in the function
+// names for the import paths.
+func unescapePath(s string) string {
+	if !strings.Contains(s, "%") {
+		return s // fast path
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '%' && i+2 < len(s) {
+			if c, err := strconv.ParseUint(s[i+1:i+3], 16, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 2
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
 // PushAsserter set [Asserter] for the current GLS (Gorounine Local Storage).
 // That allows us to have multiple different [Asserter] in use in the same
 // process.
@@ -1058,30 +2152,39 @@ func SetDefault(i Asserter) (old Asserter) {
 // function:
 //
 //	defer assert.PushAsserter(assert.Plain)()
+//
+// PushAsserter panics if i isn't a built-in or a registered [Asserter].
 func PushAsserter(i Asserter) (retFn function) {
+	mustBeAsserter(i)
+	// get pkg lvl asserter ..  to check if we are doing unit tests
+	if asserterOf(def).isUnitTesting() {
+		return PopAsserter
+	}
+	// .. allow GLS specific asserter. NOTE see current()
+	return pushAsserter(i)
+}
+
+// pushAsserter sets the [Asserter] for the current goroutine even in the unit
+// testing mode, where [PushAsserter] doesn't do it. It returns the function
+// that restores the previous one.
+func pushAsserter(i Asserter) function {
 	var (
 		prevFound    bool
 		prevAsserter asserter
-		currentGID   int
 	)
-
-	// get pkg lvl asserter
-	curAsserter := defAsserter[def]
-	// ..  to check if we are doing unit tests
-	if !curAsserter.isUnitTesting() {
-		// .. allow GLS specific asserter. NOTE see current()
-		currentGID = goid()
-		asserterMap.Tx(func(m map[int]asserter) {
-			prevAsserter, prevFound = m[currentGID]
-			m[currentGID] = defAsserter[i]
-		})
-	}
+	currentGID := goid()
+	asserterMap.Tx(func(m map[int]asserter) {
+		prevAsserter, prevFound = m[currentGID]
+		m[currentGID] = asserterOf(i)
+	})
 	if prevFound {
 		return func() {
 			asserterMap.Set(currentGID, prevAsserter)
 		}
 	}
-	return PopAsserter
+	return func() {
+		asserterMap.Del(currentGID) // no need for goid() like PopAsserter
+	}
 }
 
 // PopAsserter pops current gorounine specific [Asserter] from packages memory.
@@ -1093,14 +2196,17 @@ func PopAsserter() {
 	asserterMap.Del(goid())
 }
 
-// mapDefInd runtime asserters, that's why test asserts are removed for now.
+// mapDefInd maps the lowercase names of the asserters to them, which makes
+// the -asserter flag case-insensitive.
 var mapDefInd = map[string]Asserter{
-	"Plain": Plain,
-	"Prod":  Production,
-	"Dev":   Development,
-	//"Test":        Test,
-	//"TestFull":    TestFull,
-	"Debug": Debug,
+	"plain":       Plain,
+	"prod":        Production,
+	"production":  Production,
+	"dev":         Development,
+	"development": Development,
+	"test":        Test,
+	"testfull":    TestFull,
+	"debug":       Debug,
 }
 
 var mapDefIndToString = map[Asserter]string{
@@ -1112,12 +2218,18 @@ var mapDefIndToString = map[Asserter]string{
 	Debug:       "Debug",
 }
 
+// customAsserterString is the name of the registered asserters, see [Register].
+const customAsserterString = "Custom"
+
 func defaultAsserterString() string {
-	return mapDefIndToString[def]
+	if s, found := mapDefIndToString[def]; found {
+		return s
+	}
+	return customAsserterString
 }
 
 func newDefInd(v string) Asserter {
-	ind, found := mapDefInd[v]
+	ind, found := mapDefInd[strings.ToLower(v)]
 	if !found {
 		return Plain
 	}
@@ -1163,7 +2275,7 @@ func (f *flagAsserter) String() string {
 
 // Get is part of the flag interfaces, getter.
 func (f *flagAsserter) Get() any {
-	return mapDefIndToString[def]
+	return defaultAsserterString()
 }
 
 // Set is part of the flag.Value interface.
@@ -358,6 +358,141 @@ func ExampleNotImplemented() {
 	// Output: sample: assert_test.go:352: ExampleNotImplemented.func1(): assertion failure: not implemented
 }
 
+func ExampleDeepEqual_diff() {
+	type item struct {
+		Name  string
+		Count int
+	}
+	sample := func(got []item) (err error) {
+		defer err2.Handle(&err, "sample")
+
+		assert.DeepEqual(got, []item{{"a", 1}, {"b", 2}})
+		return err
+	}
+	err := sample([]item{{"a", 1}, {"c", 2}})
+	fmt.Printf("%v", err)
+	// Output: sample: assert_test.go:369: ExampleDeepEqual_diff.func1(): assertion failure: got and want differ:
+	//   [1].Name: "c" != "b"
+}
+
+func ExamplePanics() {
+	sample := func() (err error) {
+		defer err2.Handle(&err, "sample")
+
+		assert.Panics(func() { panic("boom") }) // OK
+		assert.Panics(func() {})                // Not OK
+		return err
+	}
+	err := sample()
+	fmt.Printf("%v", err)
+	// Output: sample: assert_test.go:383: ExamplePanics.func1(): assertion failure: function should panic
+}
+
+func ExamplePanicsWith() {
+	sample := func() (err error) {
+		defer err2.Handle(&err, "sample")
+
+		isNotFound := func(r any) bool { return r == err2.ErrNotFound }
+		assert.PanicsWith(func() { panic(err2.ErrNotFound) }, isNotFound) // OK
+		assert.PanicsWith(func() { panic(err2.ErrNotExist) }, isNotFound) // Not OK
+		return err
+	}
+	err := sample()
+	fmt.Printf("%v", err)
+	// Output: sample: assert_test.go:397: ExamplePanicsWith.func1(): assertion failure: panic value 'not exist' doesn't match
+}
+
+func ExampleNotPanics() {
+	sample := func() (err error) {
+		defer err2.Handle(&err, "sample")
+
+		assert.NotPanics(func() {})                          // OK
+		assert.NotPanics(func() { panic(err2.ErrNotFound) }) // Not OK
+		return err
+	}
+	err := sample()
+	fmt.Printf("%v", err)
+	// Output: sample: assert_test.go:410: ExampleNotPanics.func1(): assertion failure: unexpected panic: 'not found'
+}
+
+func ExampleSContains() {
+	sample := func(b []string) (err error) {
+		defer err2.Handle(&err, "sample")
+
+		assert.SContains(b, "a")    // OK
+		assert.SNotContains(b, "c") // Not OK
+		return err
+	}
+	err := sample([]string{"a", "b", "c"})
+	fmt.Printf("%v", err)
+	// Output: sample: assert_test.go:423: ExampleSContains.func1(): assertion failure: slice contains 'c' at index 2
+}
+
+func ExampleSElementsMatch() {
+	sample := func(b []int) (err error) {
+		defer err2.Handle(&err, "sample")
+
+		assert.SSubset(b, []int{3, 1})              // OK
+		assert.SElementsMatch(b, []int{3, 2, 1, 1}) // Not OK
+		return err
+	}
+	err := sample([]int{1, 2, 3, 4})
+	fmt.Printf("%v", err)
+	// Output: sample: assert_test.go:436: ExampleSElementsMatch.func1(): assertion failure: elements differ: extra [4], missing [1]
+}
+
+func ExampleSSorted() {
+	sample := func(b []int) (err error) {
+		defer err2.Handle(&err, "sample")
+
+		assert.SUnique(b) // OK
+		assert.SSorted(b) // Not OK
+		return err
+	}
+	err := sample([]int{1, 2, 5, 3})
+	fmt.Printf("%v", err)
+	// Output: sample: assert_test.go:449: ExampleSSorted.func1(): assertion failure: not sorted at index 3: '3' is after '5'
+}
+
+func ExampleMValueEquals() {
+	sample := func(b map[string]int) (err error) {
+		defer err2.Handle(&err, "sample")
+
+		assert.MKeyNotExists(b, "c")   // OK
+		assert.MValueEquals(b, "a", 2) // Not OK
+		return err
+	}
+	err := sample(map[string]int{"a": 1, "b": 2})
+	fmt.Printf("%v", err)
+	// Output: sample: assert_test.go:462: ExampleMValueEquals.func1(): assertion failure: value of key 'a': got '1', want '2'
+}
+
+func ExampleHasPrefix() {
+	sample := func(s string) (err error) {
+		defer err2.Handle(&err, "sample")
+
+		assert.Contains(s, "pool")    // OK
+		assert.HasSuffix(s, "-1")     // OK
+		assert.HasPrefix(s, "volume") // Not OK
+		return err
+	}
+	err := sample("pool-1")
+	fmt.Printf("%v", err)
+	// Output: sample: assert_test.go:476: ExampleHasPrefix.func1(): assertion failure: string 'pool-1' doesn't have prefix 'volume'
+}
+
+func ExampleMatches() {
+	sample := func(s string) (err error) {
+		defer err2.Handle(&err, "sample")
+
+		assert.Matches(s, `^[a-z][a-z0-9-]*$`) // Not OK
+		return err
+	}
+	err := sample("pool_with_a_very_long_name_that_will_be_truncated_in_the_messages")
+	fmt.Printf("%v", err)
+	// Output: sample: assert_test.go:488: ExampleMatches.func1(): assertion failure: string 'pool_with_a_very_long_name_that_will_be_truncated_in_the_mess...' doesn't match '^[a-z][a-z0-9-]*$'
+}
+
 func BenchmarkMKeyExists(b *testing.B) {
 	bs := map[int]int{0: 0, 1: 1}
 	for n := 0; n < b.N; n++ {
@@ -650,6 +785,49 @@ func BenchmarkEqualInt(b *testing.B) {
 	}
 }
 
+func BenchmarkPushAsserter(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		assert.PushAsserter(assert.Plain)()
+	}
+}
+
+// BenchmarkRequire_disabled calls current() without any goroutine specific
+// asserters, i.e., without goid().
+func BenchmarkRequire_disabled(b *testing.B) {
+	defer assert.SetContracts(assert.Production, assert.SetContracts(assert.Production, false))
+	for n := 0; n < b.N; n++ {
+		assert.Require(false)
+	}
+}
+
+// BenchmarkRequire_disabledGLS calls current() when the goroutine has its own
+// asserter, i.e., with goid().
+func BenchmarkRequire_disabledGLS(b *testing.B) {
+	defer assert.PushAsserter(assert.Plain)()
+	defer assert.SetContracts(assert.Plain, assert.SetContracts(assert.Plain, false))
+	for n := 0; n < b.N; n++ {
+		assert.Require(false)
+	}
+}
+
+// BenchmarkEqual_tester runs a passing assertion in the unit testing mode, i.e.,
+// with [assert.PushTester]. The testing context is looked up only when the
+// assertion fails.
+func BenchmarkEqual_tester(b *testing.B) {
+	defer assert.PushTester(b)()
+	for n := 0; n < b.N; n++ {
+		assert.Equal(n, n)
+	}
+}
+
+func BenchmarkCollect(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		_ = assert.Collect(func() {
+			assert.Equal(n, n)
+		})
+	}
+}
+
 func TestMain(m *testing.M) {
 	setUp()
 	code := m.Run()
@@ -1,10 +1,13 @@
 package assert
 
 import (
-	"errors"
 	"fmt"
 	"os"
+	"runtime"
+	"testing"
 
+	"github.com/lainio/err2/catalog"
+	msgs "github.com/lainio/err2/internal/catalog"
 	"github.com/lainio/err2/internal/debug"
 	"github.com/lainio/err2/internal/str"
 	"github.com/lainio/err2/internal/x"
@@ -42,8 +45,14 @@ const (
 	// compined with AsserterCallerInfo and/or AsserterStackTrace. There is
 	// variable T which have all of these three asserters.
 	asserterUnitTesting
+
+	// asserterCustom is an asserter flag for the registered Reporters. The
+	// Asserter ID of the Reporter is stored above the customShift bits.
+	asserterCustom
 )
 
+const customShift = 8
+
 // every test log or result output has 4 spaces in them
 const officialTestOutputPrefix = "    "
 
@@ -61,47 +70,122 @@ func (asserter asserter) reportAssertionFault(
 	defaultMsg string,
 	a []any,
 ) {
+	v := newViolation(extraInd, defaultMsg, nil, nil, a)
+	asserter.report(v)
+}
+
+// reportGotWant is like reportAssertionFault, but it gives the compared values
+// to the [Reporter] as well.
+func (asserter asserter) reportGotWant(
+	extraInd int,
+	defaultMsg string,
+	got, want any,
+	a []any,
+) {
+	v := newViolation(extraInd, defaultMsg, got, want, a)
+	asserter.report(v)
+}
+
+func (asserter asserter) report(v Violation) {
+	// the package defaults don't override the unit testing mode, i.e., the
+	// violations of the libraries fail the running test as well
+	a, found := packageDefault(v.Frame.Function)
+	if found && !hasGLSAsserter() && !asserter.isUnitTesting() {
+		asserter = a
+	}
+	notify(v)
+	if asserter.collect(v) {
+		return // the collector reports it later, no call stacks either
+	}
+	if asserter.isCustom() {
+		if r := reporters.Get(asserter.id()); r != nil {
+			r.Report(v)
+			return
+		}
+	}
+	asserter.Report(v)
+}
+
+// Report reports the violation according the asserter's flags. This makes the
+// built-in asserters [Reporter]s.
+func (asserter asserter) Report(v Violation) {
+	// the testing context is looked up only once per violation, because
+	// tester() is expensive, see isUnitTesting.
+	t := asserter.unitTester()
+	unitTesting := t != nil
+	s := asserter.message(v, unitTesting)
+	err := newAssertionError(s, v)
 	if asserter.hasStackTrace() {
-		if asserter.isUnitTesting() {
+		// the Reporters wrapping us add frames, so we count them from the
+		// violation's frame, and the extraInd is only the fallback.
+		extraInd := v.extraInd
+		if depth, found := frameDepth(v.Frame); found {
+			extraInd = depth - reportDepth
+		}
+		if unitTesting {
 			// Note. that the assert in the test function is printed in
 			// reportPanic below
-			const StackLvl = 5 // amount of functions before we're here
+			const StackLvl = 7 // amount of functions before we're here
 			stackLvl := StackLvl + extraInd
 			debug.PrintStackForTest(os.Stderr, stackLvl)
 		} else {
 			// amount of functions before we're here, which is different
 			// between runtime (this) and test-run (above)
-			const StackLvl = 2
+			const StackLvl = 4
 			stackLvl := StackLvl + extraInd
 			debug.PrintStack(stackLvl)
 		}
 	}
+	asserter.reportPanic(s, err, t)
+}
+
+// collect adds the violation to the collector of the current goroutine if
+// there is one, see [PushCollector]. It's done before the violation is given
+// to the [Reporter], i.e., the custom reporters are collected as well.
+func (asserter asserter) collect(v Violation) (ok bool) {
+	if collectors.Len() == 0 {
+		return false // fast path: no goid() needed
+	}
+	unitTesting := asserter.isUnitTesting()
+	return collect(newAssertionError(asserter.message(v, unitTesting), v))
+}
+
+// message builds the violation message according the asserter's flags.
+func (asserter asserter) message(v Violation, unitTesting bool) (s string) {
+	defaultMsg := v.Message
 	if asserter.hasCallerInfo() {
-		defaultMsg = asserter.callerInfo(defaultMsg, extraInd)
+		defaultMsg = asserter.callerInfo(defaultMsg, v.Frame, unitTesting)
 	}
-	if len(a) > 0 {
+	if a := v.Args; len(a) > 0 {
 		if format, ok := a[0].(string); ok {
 			allowDefMsg := !asserter.isErrorOnly() && defaultMsg != ""
 			f := x.Whom(allowDefMsg, defaultMsg+conCatErrStr+format, format)
-			asserter.reportPanic(fmt.Sprintf(f, a[1:]...))
+			s = fmt.Sprintf(f, a[1:]...)
 		} else {
-			asserter.reportPanic(fmt.Sprintln(append([]any{defaultMsg}, a...)))
+			s = fmt.Sprintln(append([]any{defaultMsg}, a...))
 		}
 	} else {
-		asserter.reportPanic(defaultMsg)
+		s = defaultMsg
+	}
+	if unitTesting && !asserter.hasCallerInfo() {
+		s = frameInfo(s, v.Frame)
 	}
+	return s
 }
 
-func (asserter asserter) reportPanic(s string) {
-	if asserter.isUnitTesting() && asserter.hasCallerInfo() {
+// reportPanic fails the test of t if it isn't nil, i.e., we are in the unit
+// testing mode. Otherwise it panics according the asserter's flags.
+func (asserter asserter) reportPanic(
+	s string,
+	err *AssertionError,
+	t testing.TB,
+) {
+	if t != nil {
 		fmt.Fprintln(os.Stderr, officialTestOutputPrefix+s)
-		tester().FailNow()
-	} else if asserter.isUnitTesting() {
-		const framesToSkip = 4 // how many fn calls there is before FuncName call
-		fatal(s, framesToSkip)
+		t.FailNow()
 	}
 	if asserter.hasToError() {
-		panic(errors.New(s))
+		panic(err)
 	}
 	panic(s)
 }
@@ -110,21 +194,39 @@ func (asserter asserter) reportPanic(s string) {
 // build according the correct test result output. framesToSkip tells how many
 // functions (stack call frames) to skip until get the function name to print.
 func fatal(s string, framesToSkip int) {
+	info := testInfo(s, framesToSkip+1) // +1 for testInfo
+	// test output goes thru stderr, no need for t.Log(), test Fail needs it.
+	fmt.Fprintln(os.Stderr, officialTestOutputPrefix+info)
+	tester().FailNow()
+}
+
+// testInfo returns the test failing msg (arg s) with the filename and the line
+// number of the caller. framesToSkip works like in fatal().
+func testInfo(s string, framesToSkip int) string {
 	const shortFmtStr = `%s:%d: %s`
 	includePath := false
 	_, filename, line, ok := str.FuncName(framesToSkip, includePath)
-	info := s
 	if ok {
-		info = fmt.Sprintf(shortFmtStr, filename, line, s)
+		return fmt.Sprintf(shortFmtStr, filename, line, s)
 	}
-	// test output goes thru stderr, no need for t.Log(), test Fail needs it.
-	fmt.Fprintln(os.Stderr, officialTestOutputPrefix+info)
-	tester().FailNow()
+	return s
+}
+
+// frameInfo returns the test failing msg (arg s) with the filename and the
+// line number of the frame.
+func frameInfo(s string, frame runtime.Frame) string {
+	if frame.File == "" {
+		return s
+	}
+	const shortFmtStr = `%s:%d: %s`
+	includePath := false
+	_, filename, line := str.FrameName(frame, includePath)
+	return fmt.Sprintf(shortFmtStr, filename, line, s)
 }
 
 var longFmtStr = `
 --------------------------------
-Assertion Fault at:
+%s
 %s:%d %s():
 %s
 --------------------------------
@@ -132,20 +234,24 @@ Assertion Fault at:
 
 var shortFmtStr = `%s:%d: %s(): %s`
 
-func (asserter asserter) callerInfo(msg string, extraInd int) (info string) {
+func (asserter asserter) callerInfo(
+	msg string,
+	frame runtime.Frame,
+	includePath bool,
+) (info string) {
 	ourFmtStr := shortFmtStr
 	if asserter.hasFormattedCallerInfo() {
 		ourFmtStr = longFmtStr
 	}
 
-	const ToSkip = 3
-	framesToSkip := ToSkip + extraInd // how many fn calls there is before FuncName call
-	includePath := asserter.isUnitTesting()
-	funcName, filename, line, ok := str.FuncName(framesToSkip, includePath)
-	if ok {
-		info = fmt.Sprintf(ourFmtStr,
-			filename, line,
-			funcName, msg)
+	if frame.File != "" {
+		funcName, filename, line := str.FrameName(frame, includePath)
+		args := []any{filename, line, funcName, msg}
+		if asserter.hasFormattedCallerInfo() {
+			faultAt := msgs.Msg(catalog.AssertionFaultAt)
+			args = append([]any{faultAt}, args...)
+		}
+		info = fmt.Sprintf(ourFmtStr, args...)
 	}
 
 	return
@@ -175,5 +281,19 @@ func (asserter asserter) hasFormattedCallerInfo() bool {
 // isUnitTesting is expensive because it calls tester(). think carefully where
 // to use it
 func (asserter asserter) isUnitTesting() bool {
-	return asserter&asserterUnitTesting != 0 && tester() != nil
+	return asserter.unitTester() != nil
+}
+
+// unitTester returns the testing context of the current goroutine if the
+// asserter is in the unit testing mode. The flag is checked first, because
+// tester() is expensive.
+func (asserter asserter) unitTester() testing.TB {
+	if asserter&asserterUnitTesting == 0 {
+		return nil
+	}
+	return tester()
+}
+
+func (asserter asserter) isCustom() bool {
+	return asserter&asserterCustom != 0
 }
@@ -0,0 +1,26 @@
+// Package assertvar publishes the assertion violation counters of the assert
+// package as expvar variables. It's a package of its own because the expvar
+// package registers its HTTP handler to the default mux, and the assert
+// package itself shouldn't have side effects like that.
+//
+//	import _ "expvar" // served at /debug/vars
+//	...
+//	assertvar.Publish("asserts")
+package assertvar
+
+import (
+	"expvar"
+
+	"github.com/lainio/err2/assert"
+)
+
+// Publish publishes the violation counters of the assert package with the
+// name as an expvar variable. The value is a JSON object where the keys are
+// the call sites and the values are the counts, see [assert.Counts].
+//
+// Note that like expvar.Publish, it panics if the name is already in use.
+func Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return assert.Counts()
+	}))
+}
@@ -0,0 +1,31 @@
+//go:build !err2_noassert
+
+package assertvar_test
+
+import (
+	"expvar"
+	"strings"
+	"testing"
+
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/assert"
+	"github.com/lainio/err2/assert/assertvar"
+	"github.com/lainio/err2/internal/expect"
+)
+
+func TestPublish(t *testing.T) {
+	t.Parallel()
+	if expvar.Get("asserts") == nil { // -count=N
+		assertvar.Publish("asserts")
+	}
+
+	sample := func() (err error) {
+		defer err2.Handle(&err, err2.Noop)
+		assert.That(false)
+		return nil
+	}
+	expect.That(t, sample() != nil)
+
+	v := expvar.Get("asserts").String()
+	expect.That(t, strings.Contains(v, `"github.com/lainio/err2/assert/assertvar_test.TestPublish.func1:24":`), v)
+}
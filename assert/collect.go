@@ -0,0 +1,161 @@
+package assert
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/lainio/err2/catalog"
+	"github.com/lainio/err2/internal/x"
+)
+
+// Violations is the error type of the collected assertion violations. Every
+// violation is an [AssertionError] of its own, and they can be inspected with
+// [errors.Is] and [errors.As] (Go 1.20+). See [Collect] and [PushCollector].
+type Violations []error
+
+// Error returns all the violation messages separated by newlines.
+func (v Violations) Error() string {
+	msgs := make([]string, len(v))
+	for i, err := range v {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the violations. It's for errors.Is and errors.As.
+func (v Violations) Unwrap() []error {
+	return v
+}
+
+// collector gathers the assertion violations of the goroutine. The previous
+// collector is stored to allow nesting.
+type collector struct {
+	violations Violations
+	prev       *collector
+}
+
+type collectorMap = map[int]*collector
+
+// collectors are the active collectors per goroutine.
+var collectors = x.NewRWMap[collectorMap]()
+
+// PushCollector starts to collect assertion violations of the current
+// goroutine, i.e., the assert functions don't stop at the first violation,
+// but they are reported all together by [PopCollector]:
+//
+//	defer assert.PushCollector()() // <- NOTE! ()()
+//	assert.Equal(u.Name, "x")
+//	assert.Equal(u.Age, 42)   // reported even when the Name is wrong
+//
+// With the unit test asserters the violations are reported as one test
+// failure that lists each location. With the runtime asserters the
+// violations are thrown as one [Violations] error that err2.Handle catches.
+//
+// Note that the assert functions return normally in the collecting mode. If
+// the code after the assert cannot run safely, e.g., it dereferences the
+// pointer that [NotNil] just checked, don't collect it.
+//
+// Note that sub-goroutines don't inherit the collector. The collectors can be
+// nested.
+func PushCollector() function {
+	gid := pushCollector()
+	return func() {
+		reportCollected(popCollector(gid)) // no need for goid() again
+	}
+}
+
+// PopCollector stops collecting the assertion violations that [PushCollector]
+// started, and reports them all together according the current [Asserter].
+// It does nothing if there are no violations.
+func PopCollector() {
+	reportCollected(popCollector(goid()))
+}
+
+// reportCollected reports the collected violations according the current
+// Asserter.
+func reportCollected(err Violations) {
+	if err == nil {
+		return
+	}
+	asserter := current()
+	if t := asserter.unitTester(); t != nil {
+		header := fmt.Sprintf(msg(catalog.Violations), len(err))
+		fmt.Fprintln(os.Stderr, officialTestOutputPrefix+header)
+		for _, v := range err {
+			fmt.Fprintln(os.Stderr, officialTestOutputPrefix+v.Error())
+		}
+		t.FailNow()
+	} else if asserter.hasToError() {
+		panic(err)
+	}
+	panic(err.Error())
+}
+
+// Collect calls the function f and returns all of the assertion violations
+// of it as one [Violations] error. If there are no violations Collect returns
+// nil. It's handy for runtime validation where you want all the field errors
+// at once:
+//
+//	defer assert.PushAsserter(assert.Plain)()
+//	err := assert.Collect(func() {
+//	     assert.NotEmpty(c.PoolName, "pool name cannot be empty")
+//	     assert.NotEmpty(c.Wallet, "wallet cannot be empty")
+//	})
+//
+// Note that Collect doesn't report the violations, it only returns them. See
+// [PushCollector] for the details of the collecting mode.
+func Collect(f func()) (err error) {
+	gid := pushCollector()
+	defer func() {
+		if v := popCollector(gid); v != nil {
+			err = v
+		}
+	}()
+	f()
+	return nil
+}
+
+// pushCollector adds the new collector for the current goroutine and returns
+// the goroutine's ID for popCollector.
+func pushCollector() (gid int) {
+	gid = goid()
+	collectors.Tx(func(m collectorMap) {
+		m[gid] = &collector{prev: m[gid]}
+	})
+	return gid
+}
+
+// popCollector removes the current collector of the goroutine gid and returns
+// its violations, or nil if there aren't any.
+func popCollector(gid int) (v Violations) {
+	collectors.Tx(func(m collectorMap) {
+		c := m[gid]
+		if c == nil {
+			return
+		}
+		if c.prev != nil {
+			m[gid] = c.prev
+		} else {
+			delete(m, gid)
+		}
+		v = c.violations
+	})
+	return v
+}
+
+// collect adds the violation to the current collector of the goroutine. It
+// returns false if there isn't a collector.
+func collect(err error) (ok bool) {
+	if collectors.Len() == 0 {
+		return false // fast path: no goid() needed
+	}
+	gid := goid()
+	collectors.Tx(func(m collectorMap) {
+		if c := m[gid]; c != nil {
+			c.violations = append(c.violations, err)
+			ok = true
+		}
+	})
+	return ok
+}
@@ -0,0 +1,54 @@
+package assert
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/lainio/err2/internal/expect"
+)
+
+// TestCollectNoStack cannot be parallel because it sets the default asserter
+// and captures the stderr.
+func TestCollectNoStack(t *testing.T) {
+	defer SetDefault(SetDefault(Production))
+	defer PushTester(t, TestFull)()
+
+	stderr := os.Stderr
+	r, w, err := os.Pipe()
+	expect.That(t, err == nil, err)
+	os.Stderr = w
+	err = Collect(func() {
+		That(false, "first")
+		That(false, "second")
+	})
+	os.Stderr = stderr
+	w.Close()
+	out, _ := io.ReadAll(r)
+
+	expect.That(t, err != nil)
+	expect.Equal(t, len(err.(Violations)), 2)
+	expect.Equal(t, string(out), "") // call stacks are not printed
+}
+
+type countReporter struct{ n int32 }
+
+func (r *countReporter) Report(Violation) { atomic.AddInt32(&r.n, 1) }
+
+func TestCollectCustomReporter(t *testing.T) {
+	t.Parallel()
+	r := &countReporter{}
+	defer PushAsserter(Register(r))()
+
+	err := Collect(func() {
+		That(false, "first")
+		Equal(1, 2, "second")
+	})
+	violations, ok := err.(Violations)
+	expect.That(t, ok, err)
+	expect.Equal(t, len(violations), 2)
+	expect.That(t, strings.HasSuffix(violations[0].Error(), "first"), violations[0])
+	expect.Equal(t, atomic.LoadInt32(&r.n), 0) // the collector reports them
+}
@@ -0,0 +1,47 @@
+package assert_test // Note!! Some tests here are related to line # of the file
+
+import (
+	"fmt"
+
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/assert"
+)
+
+func ExampleCollect() {
+	type config struct {
+		PoolName string
+		Wallet   string
+		Port     int
+	}
+	validate := func(c config) error {
+		defer assert.PushAsserter(assert.Plain)()
+
+		return assert.Collect(func() {
+			assert.NotEmpty(c.PoolName, "pool name cannot be empty")
+			assert.NotEmpty(c.Wallet, "wallet cannot be empty")
+			assert.Between(c.Port, 1, 65535, "port must be 1..65535")
+		})
+	}
+	err := validate(config{Wallet: "w"})
+	fmt.Printf("%v\n", err)
+	err = validate(config{PoolName: "p", Wallet: "w", Port: 80})
+	fmt.Printf("%v", err)
+	// Output: pool name cannot be empty
+	// port must be 1..65535
+	// <nil>
+}
+
+func ExamplePushCollector() {
+	sample := func(name string, age int) (err error) {
+		defer err2.Handle(&err, "sample")
+		defer assert.PushCollector()()
+
+		assert.Equal(name, "x")
+		assert.Equal(age, 42)
+		return err
+	}
+	err := sample("y", 41)
+	fmt.Printf("%v", err)
+	// Output: sample: collect_test.go:39: assert_test.ExamplePushCollector.func1(): assertion failure: equal: got 'y', want 'x'
+	// collect_test.go:40: assert_test.ExamplePushCollector.func1(): assertion failure: equal: got '41', want '42'
+}
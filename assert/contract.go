@@ -0,0 +1,129 @@
+package assert
+
+import (
+	"github.com/lainio/err2/catalog"
+	"github.com/lainio/err2/internal/x"
+)
+
+// Invariant is the interface for the types that have an invariant, i.e., a
+// condition that must hold before and after every (public) method call. See
+// [CheckInvariant].
+type Invariant interface {
+	// Invariant returns true if the invariant of the object holds.
+	Invariant() bool
+}
+
+type contractsMap = map[Asserter]bool
+
+// contractsOff are the Asserters that have the contract checks disabled.
+var contractsOff = x.NewRWMap[contractsMap]()
+
+// SetContracts enables or disables the contract checks ([Require], [Ensure]
+// and [CheckInvariant]) for the [Asserter], and returns the previous state.
+// The contracts are enabled for all asserters by default. For example, if you
+// want to zero the cost of the contracts in production:
+//
+//	assert.SetContracts(assert.Production, false)
+//
+// Note that when contracts are disabled the condition functions aren't
+// called at all. Note also that while any of the asserters has the contracts
+// disabled, every contract check needs the current [Asserter], which costs a
+// goroutine ID lookup if goroutine specific asserters ([PushAsserter]) are in
+// use.
+//
+// SetContracts panics if a isn't a built-in or a registered [Asserter].
+func SetContracts(a Asserter, on bool) (old bool) {
+	mustBeAsserter(a)
+	contractsOff.Tx(func(m contractsMap) {
+		old = !m[a]
+		if on {
+			delete(m, a)
+		} else {
+			m[a] = true
+		}
+	})
+	return old
+}
+
+// contractsOn returns true if the contract checks are enabled for the current
+// Asserter.
+func contractsOn() bool {
+	if contractsOff.Len() == 0 {
+		return true // fast path: nothing is disabled
+	}
+	return !contractsOff.Get(current().id())
+}
+
+// Require asserts the precondition of the function. If the term isn't true it
+// panics/errors (according the current [Asserter]) with the auto-generated
+// message that tells that it was a precondition violation:
+//
+//	func (c *Chain) Invite(...) {
+//	     assert.Require(c.isLeaf(invitersKey), "only leaf can invite")
+//
+// Require can be disabled per [Asserter] with [SetContracts].
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func Require(term bool, a ...any) {
+	if enabled && !term && contractsOn() {
+		doContract(catalog.Precondition, a)
+	}
+}
+
+// Ensure asserts the postcondition of the function. It's meant to be
+// deferred, and then the cond function is called at the function exit. If it
+// returns false Ensure panics/errors (according the current [Asserter]) with
+// the auto-generated message that tells that it was a postcondition violation:
+//
+//	func (s *Stack) Push(v int) {
+//	     defer assert.Ensure(func() bool { return s.Top() == v })
+//
+// Ensure can be disabled per [Asserter] with [SetContracts], and then the cond
+// isn't called.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func Ensure(cond func() bool, a ...any) {
+	if enabled && contractsOn() && !cond() {
+		doContract(catalog.Postcondition, a)
+	}
+}
+
+// CheckInvariant asserts the invariant of the object on the function entry,
+// and returns the function that asserts it on the exit. That allows the
+// one-liner:
+//
+//	func (s *Stack) Push(v int) {
+//	     defer assert.CheckInvariant(s)() // <- NOTE! ()()
+//
+// If the invariant doesn't hold it panics/errors (according the current
+// [Asserter]) with the auto-generated message that tells if it was violated
+// on the entry or on the exit.
+//
+// CheckInvariant can be disabled per [Asserter] with [SetContracts], and then
+// the Invariant method isn't called.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func CheckInvariant(obj Invariant, a ...any) function {
+	if !enabled {
+		return func() {}
+	}
+	if contractsOn() && !obj.Invariant() {
+		doContract(catalog.InvariantEntry, a)
+	}
+	return func() {
+		if contractsOn() && !obj.Invariant() {
+			doContract(catalog.InvariantExit, a)
+		}
+	}
+}
+
+func doContract(id catalog.ID, a []any) {
+	defMsg := assertionMsg(id)
+	current().reportAssertionFault(1, defMsg, a)
+}
@@ -0,0 +1,69 @@
+package assert_test // Note!! Some tests here are related to line # of the file
+
+import (
+	"fmt"
+
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/assert"
+)
+
+type stack struct {
+	items []int
+	size  int
+}
+
+func (s *stack) Invariant() bool {
+	return len(s.items) == s.size
+}
+
+func (s *stack) push(v int) {
+	defer assert.CheckInvariant(s)()
+	defer assert.Ensure(func() bool { return s.items[len(s.items)-1] == v })
+
+	s.items = append(s.items, v)
+	if v != 13 { // bug: unlucky numbers aren't counted
+		s.size++
+	}
+}
+
+func ExampleRequire() {
+	sample := func(b []byte) (err error) {
+		defer err2.Handle(&err, "sample")
+
+		assert.Require(len(b) > 0, "buffer cannot be empty")
+		return err
+	}
+	err := sample(nil)
+	fmt.Printf("%v", err)
+	// Output: sample: contract_test.go:33: assert_test.ExampleRequire.func1(): assertion failure: precondition violated: buffer cannot be empty
+}
+
+func ExampleCheckInvariant() {
+	sample := func() (err error) {
+		defer err2.Handle(&err, "sample")
+
+		s := &stack{}
+		s.push(1)
+		s.push(13)
+		return err
+	}
+	err := sample()
+	fmt.Printf("%v", err)
+	// Output: sample: contract_test.go:27: assert_test.(*stack).push(): assertion failure: invariant violated on exit
+}
+
+func ExampleSetContracts() {
+	sample := func() (err error) {
+		defer err2.Handle(&err, "sample")
+
+		s := &stack{}
+		s.push(13)
+		return err
+	}
+	old := assert.SetContracts(assert.Production, false)
+	defer assert.SetContracts(assert.Production, old)
+
+	err := sample()
+	fmt.Printf("%v", err)
+	// Output: <nil>
+}
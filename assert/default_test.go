@@ -0,0 +1,100 @@
+package assert
+
+import (
+	"os"
+	"testing"
+
+	"github.com/lainio/err2/internal/expect"
+)
+
+func TestNewDefInd(t *testing.T) {
+	t.Parallel()
+	expect.Equal(t, newDefInd("Prod"), Production)
+	expect.Equal(t, newDefInd("production"), Production)
+	expect.Equal(t, newDefInd("DEV"), Development)
+	expect.Equal(t, newDefInd("test"), Test)
+	expect.Equal(t, newDefInd("TestFull"), TestFull)
+	expect.Equal(t, newDefInd("debug"), Debug)
+	expect.Equal(t, newDefInd("unknown"), Plain)
+}
+
+func TestFuncPackage(t *testing.T) {
+	t.Parallel()
+	expect.Equal(t, funcPackage("github.com/some/lib/pkg.(*Type).Method"),
+		"github.com/some/lib/pkg")
+	expect.Equal(t, funcPackage("github.com/some/lib.v2/pkg.Func.func1"),
+		"github.com/some/lib.v2/pkg")
+	expect.Equal(t, funcPackage("main.main"), "main")
+	expect.Equal(t, funcPackage("gopkg.in/yaml%2ev3.Unmarshal"), "gopkg.in/yaml.v3")
+	expect.Equal(t, funcPackage("example.com/a%2eb%2ec.(*T).M"), "example.com/a.b.c")
+	expect.Equal(t, funcPackage("example.com/bad%2.F"), "example.com/bad%2")
+}
+
+// TestSetPackageDefault cannot be parallel because it sets the package default
+// for the package itself.
+func TestSetPackageDefault(t *testing.T) {
+	if !enabled {
+		t.Skip("the asserts are disabled with the err2_noassert tag")
+	}
+	const pkg = "github.com/lainio/err2/assert"
+	defer ResetPackageDefault("github.com/some/...")
+	expect.Equal(t, SetPackageDefault("github.com/some/...", Debug), def)
+
+	a, found := packageDefault("github.com/some/lib/pkg.Func")
+	expect.That(t, found)
+	expect.Equal(t, a, dbg)
+	_, found = packageDefault("github.com/other/lib/pkg.Func")
+	expect.ThatNot(t, found)
+
+	sample := func() (r any) {
+		defer func() { r = recover() }()
+		That(false, "plain message")
+		return nil
+	}
+	expect.That(t, sample() != "plain message")
+
+	defer ResetPackageDefault(pkg)
+	SetPackageDefault(pkg, Plain)
+	expect.That(t, sample().(error).Error() == "plain message")
+
+	func() {
+		defer PushAsserter(Debug)() // GLS asserter overrides package default
+		_, isErr := sample().(error)
+		expect.ThatNot(t, isErr)
+	}()
+}
+
+type failTB struct {
+	testing.TB
+	failed bool
+}
+
+func (t *failTB) FailNow() { t.failed = true }
+
+// TestPackageDefaultTester cannot be parallel because it sets the package
+// default for the package itself and the default asserter.
+func TestPackageDefaultTester(t *testing.T) {
+	if !enabled {
+		t.Skip("the asserts are disabled with the err2_noassert tag")
+	}
+	const pkg = "github.com/lainio/err2/assert"
+	defer ResetPackageDefault(pkg)
+	SetPackageDefault(pkg, Plain)
+	defer SetDefault(SetDefault(Production))
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	expect.That(t, err == nil, err)
+	defer devNull.Close()
+	defer func(f *os.File) { os.Stderr = f }(os.Stderr)
+	os.Stderr = devNull
+
+	tb := &failTB{TB: t}
+	defer PushTester(tb, Test)()
+	r, panicked, _ := catchPanic(func() {
+		That(false, "plain message")
+	})
+	expect.That(t, panicked)
+	expect.That(t, tb.failed) // the test fails instead of the Plain error
+	_, isErr := r.(error)
+	expect.ThatNot(t, isErr)
+}
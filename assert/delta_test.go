@@ -0,0 +1,113 @@
+package assert
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lainio/err2/internal/expect"
+)
+
+func TestInDeltaInt(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name             string
+		val, want, delta int64
+		ok               bool
+	}{
+		{"equal", 5, 5, 0, true},
+		{"within", 5, 7, 2, true},
+		{"outside", 5, 8, 2, false},
+		{"negative delta", 5, 5, -1, false},
+		{"extremes", math.MaxInt64, math.MinInt64, 0, false},
+		{"extremes max delta", math.MaxInt64, math.MinInt64, math.MaxInt64, false},
+		{"min to zero", math.MinInt64, 0, math.MaxInt64, false},
+		{"max to zero", math.MaxInt64, 0, math.MaxInt64, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			expect.Equal(t, inDelta(tt.val, tt.want, tt.delta), tt.ok)
+		})
+	}
+}
+
+func TestInDeltaSmallInt(t *testing.T) {
+	t.Parallel()
+	expect.ThatNot(t, inDelta(int8(100), int8(-100), int8(10)))
+	expect.ThatNot(t, inDelta(int8(math.MaxInt8), int8(math.MinInt8), int8(math.MaxInt8)))
+	expect.That(t, inDelta(int8(-100), int8(-110), int8(10)))
+	expect.ThatNot(t, inDelta(uint8(0), uint8(255), uint8(254)))
+	expect.That(t, inDelta(uint8(255), uint8(0), uint8(255)))
+	expect.ThatNot(t, inDelta(uint64(0), uint64(math.MaxUint64), 1))
+	expect.Equal(t, intDist(int64(math.MaxInt64), int64(math.MinInt64)),
+		uint64(math.MaxUint64))
+}
+
+func TestInDeltaFloat(t *testing.T) {
+	t.Parallel()
+	inf, nan := math.Inf(1), math.NaN()
+	tests := []struct {
+		name             string
+		val, want, delta float64
+		ok               bool
+	}{
+		{"within", 1.0, 1.05, 0.1, true},
+		{"outside", 1.0, 1.2, 0.1, false},
+		{"equal inf", inf, inf, 0, true},
+		{"inf with inf delta", inf, 1, inf, false},
+		{"inf want", 1, inf, inf, false},
+		{"opposite inf", inf, -inf, inf, false},
+		{"nan", nan, nan, inf, false},
+		{"nan delta", 1, 1, nan, false},
+		{"huge", math.MaxFloat64, -math.MaxFloat64, math.MaxFloat64, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			expect.Equal(t, inDelta(tt.val, tt.want, tt.delta), tt.ok)
+		})
+	}
+}
+
+func TestInEpsilonInf(t *testing.T) {
+	t.Parallel()
+	inf := math.Inf(1)
+	expect.That(t, inEpsilon(inf, inf, 0))
+	expect.ThatNot(t, inEpsilon(inf, 1, inf))
+	expect.ThatNot(t, inEpsilon(1, inf, inf))
+	expect.ThatNot(t, inEpsilon(math.NaN(), 1, inf))
+}
+
+func TestDoInDeltaMessage(t *testing.T) {
+	t.Parallel()
+	defer PushAsserter(Plain)()
+	r, _, _ := catchPanic(func() {
+		InDelta(int8(100), int8(-100), int8(10))
+	})
+	err, _ := r.(error)
+	expect.That(t, err != nil)
+	expect.Equal(t, err.Error(),
+		"assertion failure: got '100', want '-100' ± '10', difference '200'")
+}
+
+func TestZeroGotWant(t *testing.T) {
+	t.Parallel()
+	defer PushAsserter(Plain)()
+	tests := []struct {
+		name string
+		f    func()
+		got  int
+	}{
+		{"Zero", func() { Zero(1) }, 1},
+		{"NotZero", func() { NotZero(0) }, 0},
+	}
+	for _, tt := range tests {
+		r, _, _ := catchPanic(tt.f)
+		err, _ := r.(*AssertionError)
+		expect.That(t, err != nil, tt.name)
+		expect.That(t, err.Got == tt.got, tt.name, err.Got)
+		expect.That(t, err.Want == 0, tt.name, err.Want)
+	}
+}
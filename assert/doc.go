@@ -15,6 +15,15 @@ sub-gouroutines:
 	     go func() {
 	          assert.PushTester(t)() // <-- Needs to do again for a new goroutine
 
+If you prefer, [UseTester] registers the cleanup with t.Cleanup, and [Go] starts
+goroutines that inherit the testing context automatically:
+
+	func TestInvite(t *testing.T) {
+	     assert.UseTester(t) // no defer needed
+	     ...
+	     assert.Go(func() {
+	          assert.Equal(alice.Len(), 1) // reported to TestInvite
+
 # Merge Runtime And Unit Test Assertions
 
 The next block is the actual Invite function's first two lines. Even if the
@@ -65,21 +74,52 @@ error messages and diagnostic they need.
 	// Information is transported thru error values when err2.Handle is in use.
 	assert.SetDefault(assert.Production)
 
+The errors that the asserters throw are [AssertionError]s, which allows you to
+route the assertion violations with [errors.As] or to recognize them with
+[ErrAssertion], e.g., to answer HTTP 400 for the validation failures.
+
 Please see the code examples for more information.
 
+Because [SetDefault] is for the whole process, you can override it per
+package with [SetPackageDefault], e.g., to keep a library in the Production
+mode while your app runs in the Development mode.
+
 Note that if an [Asserter] is set for a goroutine level, it cannot be changed
 with the -asserter flag or [SetDefault]. The GLS [Asserter] is used for a
 reason, so it's good that even a unit test asserter won't override it in those
 cases.
 
+# Soft Assertions
+
+By default the first assertion violation stops the execution. If you want to
+get all of the violations at once, use [PushCollector] in tests, or [Collect]
+for runtime validation:
+
+	err := assert.Collect(func() {
+	     assert.NotEmpty(c.PoolName, "pool name cannot be empty")
+	     assert.NotEmpty(c.Wallet, "wallet cannot be empty")
+	})
+
+For the command and API validation, [Validate] maps the violations to the
+fields with the [ValidationErrors] error type.
+
+# Observability
+
+Every assertion violation is counted by its call site, see [Counts], and the
+hooks registered with [AddHook] are called before the violation is reported.
+The assertvar sub-package publishes the counters as expvar variables.
+
 # Flag Package Support
 
 The assert package supports Go's flags. All you need to do is to call
 [flag.Parse]. And the following flags are supported (="default-value"):
 
 	-asserter="Prod"
-	    A name of the asserter Plain, Prod, Dev, Debug
-	    See more information from constants: Plain, Production, Development, Debug
+	    A name of the asserter Plain, Prod, Dev, Test, TestFull, Debug
+	    See more information from constants: Plain, Production, Development,
+	    Test, TestFull, Debug. The names are case-insensitive.
+	-err2-update=false
+	    Update the golden files of assert.Golden instead of comparing them
 
 And assert package's configuration flags are inserted.
 
@@ -92,6 +132,21 @@ We should prefer specialized versions like [assert.Equal] that we get precise
 and readable error messages automatically. Error messagas follow Go idiom of
 'got xx, want yy'. And we still can annotate error message if we want.
 
+The goroutine specific state, i.e., [PushAsserter], [PushTester] and
+[PushCollector], needs the goroutine ID, which isn't cheap to get in Go. That's
+why it's looked up only when some goroutine has its own state. Without it, the
+asserts use the package level [Asserter] directly.
+
+If even the if-statement is too much, e.g., in the hot loops of a release
+build, use the err2_noassert build tag:
+
+	go build -tags err2_noassert ./...
+
+With the tag the assert functions are empty stubs that the compiler removes.
+The API stays the same, and the functions that return values, like
+[MKeyExists] and [ErrorAs], still return them. Note that the arguments are
+still evaluated.
+
 # Naming
 
 Because performance has been number one requirement and Go's generics cannot
@@ -0,0 +1,7 @@
+//go:build !err2_noassert
+
+package assert
+
+// enabled tells that the assert functions check their conditions. See the
+// err2_noassert build tag from the package documentation.
+const enabled = true
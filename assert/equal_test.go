@@ -0,0 +1,52 @@
+package assert_test // Note!! Some tests here are related to line # of the file
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/assert"
+)
+
+func ExampleEqualFunc() {
+	type user struct {
+		Name  string
+		Roles []string
+	}
+	sameUser := func(a, b user) bool {
+		return strings.EqualFold(a.Name, b.Name) &&
+			strings.Join(a.Roles, ",") == strings.Join(b.Roles, ",")
+	}
+	sample := func(got user) (err error) {
+		defer err2.Handle(&err, "sample")
+
+		assert.EqualFunc(got, user{Name: "alice", Roles: []string{"admin"}}, sameUser)
+		return err
+	}
+	err := sample(user{Name: "Alice", Roles: []string{"admin"}})
+	fmt.Printf("%v\n", err)
+	err = sample(user{Name: "Alice", Roles: []string{"user"}})
+	fmt.Printf("%v", err)
+	// Output: <nil>
+	// sample: equal_test.go:24: assert_test.ExampleEqualFunc.func2(): assertion failure: equal: got and want differ:
+	//   .Name: "Alice" != "alice"
+	//   .Roles[0]: "user" != "admin"
+}
+
+func ExampleEqualMethod() {
+	sample := func(got time.Time) (err error) {
+		defer err2.Handle(&err, "sample")
+
+		want := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		assert.EqualMethod(got, want)
+		return err
+	}
+	helsinki := time.FixedZone("EET", 2*60*60)
+	err := sample(time.Date(2024, 1, 2, 5, 4, 5, 0, helsinki))
+	fmt.Printf("%v\n", err)
+	err = sample(time.Date(2024, 1, 2, 3, 4, 5, 0, helsinki))
+	fmt.Printf("%v", err)
+	// Output: <nil>
+	// sample: equal_test.go:42: assert_test.ExampleEqualMethod.func1(): assertion failure: equal: got '2024-01-02 03:04:05 +0200 EET', want '2024-01-02 03:04:05 +0000 UTC'
+}
@@ -0,0 +1,82 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrAssertion is the sentinel error that all of the [AssertionError]s match.
+// It allows you to recognize assertion violations with [errors.Is] or
+// [github.com/lainio/err2/try.Is]:
+//
+//	if errors.Is(err, assert.ErrAssertion) {
+//	     http.Error(w, err.Error(), http.StatusBadRequest)
+//	}
+var ErrAssertion = errors.New("assertion violation")
+
+// AssertionError is the error type of the assertion violations that the
+// built-in asserters, e.g., [Production] and [Plain], throw. Its Error
+// message is built according the [Asserter]. The fields allow you to route
+// the violations with [errors.As]:
+//
+//	var ae *assert.AssertionError
+//	if errors.As(err, &ae) {
+//	     log.Printf("%s failed at %s:%d", ae.Kind, ae.File, ae.Line)
+//	}
+type AssertionError struct {
+	// Kind is the name of the assert function, e.g., "Equal" or "SLen".
+	Kind string
+
+	// Got and Want are the compared values if the assert function has them.
+	// Otherwise they are nil.
+	Got, Want any
+
+	// File, Line and Function tell the location of the assertion violation,
+	// i.e., the caller of the assert function.
+	File     string
+	Line     int
+	Function string
+
+	// Message is the user message built from the optional arguments of the
+	// assert function. It's empty if there were no arguments.
+	Message string
+
+	msg string
+}
+
+// Error returns the assertion violation message as the [Asserter] built it.
+func (e *AssertionError) Error() string {
+	return e.msg
+}
+
+// Is returns true for the [ErrAssertion].
+func (e *AssertionError) Is(target error) bool {
+	return target == ErrAssertion
+}
+
+// newAssertionError returns the AssertionError of the violation with the
+// message s.
+func newAssertionError(s string, v Violation) *AssertionError {
+	return &AssertionError{
+		Kind:     v.Kind,
+		Got:      v.Got,
+		Want:     v.Want,
+		File:     v.Frame.File,
+		Line:     v.Frame.Line,
+		Function: v.Frame.Function,
+		Message:  userMsg(v.Args),
+		msg:      s,
+	}
+}
+
+// userMsg returns the message built from the optional arguments of the assert
+// function.
+func userMsg(a []any) string {
+	if len(a) == 0 {
+		return ""
+	}
+	if format, ok := a[0].(string); ok {
+		return fmt.Sprintf(format, a[1:]...)
+	}
+	return fmt.Sprint(a...)
+}
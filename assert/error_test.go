@@ -0,0 +1,106 @@
+package assert_test // Note!! Some tests here are related to line # of the file
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/assert"
+	"github.com/lainio/err2/internal/expect"
+)
+
+func ExampleErrorIs() {
+	sample := func(err error) (rerr error) {
+		defer err2.Handle(&rerr, "sample")
+
+		assert.ErrorIs(err, os.ErrNotExist)
+		return nil
+	}
+	err := sample(fmt.Errorf("read config: %w", os.ErrPermission))
+	fmt.Printf("%v", err)
+	// Output: sample: error_test.go:22: assert_test.ExampleErrorIs.func1(): assertion failure: error chain doesn't include 'file does not exist'
+	// error chain:
+	//   *fmt.wrapError: "read config: permission denied"
+	//     *errors.errorString: "permission denied"
+}
+
+type multiErr []error
+
+func (m multiErr) Error() string   { return "multi" }
+func (m multiErr) Unwrap() []error { return m }
+
+func ExampleErrorAs() {
+	sample := func(err error) (rerr error) {
+		defer err2.Handle(&rerr, "sample")
+
+		pathErr := assert.ErrorAs[*fs.PathError](err) // OK
+		fmt.Println(pathErr.Path)
+		_ = assert.ErrorAs[*strconv.NumError](err) // Not OK
+		return nil
+	}
+	pathErr := &fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist}
+	err := sample(multiErr{io.EOF, pathErr})
+	fmt.Printf("%v", err)
+	// Output: x
+	// sample: error_test.go:44: assert_test.ExampleErrorAs.func1(): assertion failure: error chain doesn't include type '*strconv.NumError'
+	// error chain:
+	//   assert_test.multiErr: "multi"
+	//     *errors.errorString: "EOF"
+	//     *fs.PathError: "open x: file does not exist"
+	//       *errors.errorString: "file does not exist"
+}
+
+func ExampleErrorContains() {
+	sample := func(err error) (rerr error) {
+		defer err2.Handle(&rerr, "sample")
+
+		assert.ErrorContains(err, "EOF")     // OK
+		assert.ErrorContains(err, "timeout") // Not OK
+		return nil
+	}
+	err := sample(io.EOF)
+	fmt.Printf("%v", err)
+	// Output: sample: error_test.go:64: assert_test.ExampleErrorContains.func1(): assertion failure: error message 'EOF' doesn't contain 'timeout'
+	// error chain:
+	//   *errors.errorString: "EOF"
+}
+
+func TestAssertionError(t *testing.T) {
+	t.Parallel()
+	sample := func(age int) (err error) {
+		defer err2.Handle(&err, err2.Noop)
+		defer assert.PushAsserter(assert.Production)()
+
+		assert.Equal(age, 42, "age of %s", "alice")
+		return nil
+	}
+	err := sample(41)
+	expect.That(t, errors.Is(err, assert.ErrAssertion))
+
+	var ae *assert.AssertionError
+	expect.That(t, errors.As(err, &ae))
+	expect.Equal(t, ae.Kind, "Equal")
+	expect.That(t, ae.Got == 41, ae.Got)
+	expect.That(t, ae.Want == 42, ae.Want)
+	expect.Equal(t, filepath.Base(ae.File), "error_test.go")
+	expect.Equal(t, ae.Line, 80)
+	expect.Equal(t, filepath.Base(ae.Function), "assert_test.TestAssertionError.func1")
+	expect.Equal(t, ae.Message, "age of alice")
+	expect.Equal(t, ae.Error(), err.Error())
+
+	err = assert.Collect(func() {
+		defer assert.PushAsserter(assert.Plain)()
+		assert.That(false, "first")
+		assert.NotEmpty("", "second")
+	})
+	expect.That(t, errors.Is(err, assert.ErrAssertion))
+	expect.That(t, errors.As(err, &ae))
+	expect.Equal(t, ae.Kind, "That")
+	expect.Equal(t, ae.Message, "first")
+}
@@ -0,0 +1,231 @@
+package assert
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/lainio/err2/catalog"
+	"github.com/lainio/err2/internal/x"
+)
+
+// Clock is the time source of the polling asserts like [Eventually] and
+// [Never]. You can set your own implementation with [PushClock] or [SetClock]
+// to make your unit tests deterministic.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// Sleep pauses the current goroutine for the duration d.
+	Sleep(d time.Duration)
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time        { return time.Now() }
+func (realClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+type clockMap = map[int]Clock
+
+var (
+	clockValue atomic.Value
+
+	// clocks are the goroutine specific clocks set by PushClock.
+	clocks = x.NewRWMap[clockMap]()
+)
+
+func init() {
+	SetClock(nil)
+}
+
+// SetClock sets the time source for the polling asserts of all goroutines and
+// returns the previous one. The nil resets the real time source. Because the
+// clock is package level state, the tests that use SetClock must not call
+// t.Parallel(), and they affect all of the other polling asserts of the
+// process. Prefer [PushClock] in the unit tests.
+//
+// Note that the returned clock is the previous one, which allows you to restore
+// it with: defer assert.SetClock(assert.SetClock(c)).
+func SetClock(c Clock) (old Clock) {
+	if c == nil {
+		c = realClock{}
+	}
+	if prev := clockValue.Swap(&c); prev != nil {
+		old = *prev.(*Clock)
+	}
+	return old
+}
+
+// PushClock sets the time source for the polling asserts of the current
+// goroutine, like [PushAsserter] sets the [Asserter]. It overrides [SetClock],
+// and it doesn't affect the other goroutines, i.e., the parallel tests can use
+// their own clocks:
+//
+//	defer assert.PushClock(myFakeClock)()
+//
+// The returned function restores the previous clock of the goroutine.
+func PushClock(c Clock) (pop function) {
+	gid := goid()
+	var (
+		prev      Clock
+		prevFound bool
+	)
+	clocks.Tx(func(m clockMap) {
+		prev, prevFound = m[gid]
+		m[gid] = c
+	})
+	if prevFound {
+		return func() {
+			clocks.Set(gid, prev)
+		}
+	}
+	return func() {
+		clocks.Del(gid)
+	}
+}
+
+func clock() Clock {
+	if clocks.Len() > 0 { // goid() only if some goroutine has its own clock
+		if c := clocks.Get(goid()); c != nil {
+			return c
+		}
+	}
+	return *clockValue.Load().(*Clock)
+}
+
+// Eventually asserts that the condition becomes true within the timeout. The
+// condition is polled at every interval. If it doesn't become true it
+// panics/errors (according the current [Asserter]) with the auto-generated
+// message that tells how long and how many times the condition was polled:
+//
+//	assert.Eventually(func() bool { return srv.Ready() },
+//	     time.Second, 10*time.Millisecond)
+//
+// The interval must be positive, and the last poll happens at the timeout. See
+// [PushClock] for deterministic unit tests.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func Eventually(cond func() bool, timeout, interval time.Duration, a ...any) {
+	if !enabled {
+		return
+	}
+	if interval <= 0 {
+		doInvalidInterval(interval, a)
+		return
+	}
+	if ok, elapsed, polls := poll(cond, timeout, interval); !ok {
+		doEventually(catalog.NotEventually, elapsed, polls, a)
+	}
+}
+
+// Never asserts that the condition stays false for the whole timeout. The
+// condition is polled at every interval. If it becomes true it panics/errors
+// (according the current [Asserter]) with the auto-generated message that tells
+// how long and how many times the condition was polled before it became true.
+//
+// See [PushClock] for deterministic unit tests.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func Never(cond func() bool, timeout, interval time.Duration, a ...any) {
+	if !enabled {
+		return
+	}
+	if interval <= 0 {
+		doInvalidInterval(interval, a)
+		return
+	}
+	if ok, elapsed, polls := poll(cond, timeout, interval); ok {
+		doEventually(catalog.NotNever, elapsed, polls, a)
+	}
+}
+
+func doInvalidInterval(interval time.Duration, a []any) {
+	defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.InvalidInterval), interval)
+	current().reportAssertionFault(1, defMsg, a)
+}
+
+func doEventually(id catalog.ID, elapsed time.Duration, polls int, a []any) {
+	defMsg := fmt.Sprintf(assertionMsg()+msg(id), elapsed, polls)
+	current().reportAssertionFault(1, defMsg, a)
+}
+
+// EventuallyEqual asserts that the value returned by get becomes equal to want
+// within the timeout. The value is polled at every interval. If it doesn't
+// become equal it panics/errors (according the current [Asserter]) with the
+// auto-generated message that includes the last observed value:
+//
+//	assert.EventuallyEqual(counter.Load, 10, time.Second, time.Millisecond)
+//
+// See [PushClock] for deterministic unit tests.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func EventuallyEqual[T comparable](
+	get func() T,
+	want T,
+	timeout, interval time.Duration,
+	a ...any,
+) {
+	if !enabled {
+		return
+	}
+	if interval <= 0 {
+		doInvalidInterval(interval, a)
+		return
+	}
+	var last T
+	cond := func() bool {
+		last = get()
+		return last == want
+	}
+	if ok, elapsed, polls := poll(cond, timeout, interval); !ok {
+		doEventuallyEqual(last, want, elapsed, polls, a)
+	}
+}
+
+func doEventuallyEqual[T comparable](
+	last, want T,
+	elapsed time.Duration,
+	polls int,
+	a []any,
+) {
+	f := assertionMsg() + msg(catalog.NotEventuallyEqual)
+	defMsg := fmt.Sprintf(f, last, want, elapsed, polls)
+	current().reportGotWant(1, defMsg, last, want, a)
+}
+
+// poll calls the cond at every interval until it returns true or the timeout
+// is reached. The cond is always called at least once, and the last sleep is
+// shortened to end at the timeout. The interval must be positive.
+func poll(
+	cond func() bool,
+	timeout, interval time.Duration,
+) (ok bool, elapsed time.Duration, polls int) {
+	return pollWith(clock(), cond, timeout, interval)
+}
+
+func pollWith(
+	c Clock,
+	cond func() bool,
+	timeout, interval time.Duration,
+) (ok bool, elapsed time.Duration, polls int) {
+	start := c.Now()
+	for {
+		polls++
+		ok = cond()
+		elapsed = c.Now().Sub(start)
+		if ok || elapsed >= timeout {
+			return ok, elapsed, polls
+		}
+		if remaining := timeout - elapsed; remaining < interval {
+			c.Sleep(remaining)
+		} else {
+			c.Sleep(interval)
+		}
+	}
+}
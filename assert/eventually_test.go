@@ -0,0 +1,67 @@
+package assert_test // Note!! Some tests here are related to line # of the file
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/assert"
+)
+
+// fakeClock is deterministic time source for the polling asserts.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time        { return c.now }
+func (c *fakeClock) Sleep(d time.Duration) { c.now = c.now.Add(d) }
+
+func ExampleEventually() {
+	defer assert.PushClock(&fakeClock{})()
+
+	sample := func() (err error) {
+		defer err2.Handle(&err, "sample")
+
+		n := 0
+		ready := func() bool { n++; return n > 3 }
+		assert.Eventually(ready, time.Second, 100*time.Millisecond) // OK
+		never := func() bool { return false }
+		assert.Eventually(never, time.Second, 100*time.Millisecond) // Not OK
+		return err
+	}
+	err := sample()
+	fmt.Printf("%v", err)
+	// Output: sample: eventually_test.go:29: assert_test.ExampleEventually.func1(): assertion failure: condition not satisfied in 1s after 11 polls
+}
+
+func ExampleNever() {
+	defer assert.PushClock(&fakeClock{})()
+
+	sample := func() (err error) {
+		defer err2.Handle(&err, "sample")
+
+		n := 0
+		failing := func() bool { n++; return n > 3 }
+		assert.Never(failing, time.Second, 100*time.Millisecond) // Not OK
+		return err
+	}
+	err := sample()
+	fmt.Printf("%v", err)
+	// Output: sample: eventually_test.go:45: assert_test.ExampleNever.func1(): assertion failure: condition satisfied in 300ms after 4 polls
+}
+
+func ExampleEventuallyEqual() {
+	defer assert.PushClock(&fakeClock{})()
+
+	sample := func() (err error) {
+		defer err2.Handle(&err, "sample")
+
+		n := 0
+		counter := func() int { n++; return n }
+		assert.EventuallyEqual(counter, 100, time.Second, 250*time.Millisecond) // Not OK
+		return err
+	}
+	err := sample()
+	fmt.Printf("%v", err)
+	// Output: sample: eventually_test.go:61: assert_test.ExampleEventuallyEqual.func1(): assertion failure: got '5', want '100' in 1s after 5 polls
+}
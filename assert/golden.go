@@ -0,0 +1,84 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lainio/err2/catalog"
+	"github.com/lainio/err2/internal/diff"
+)
+
+// updateFlag is the name of the flag that sets updateGolden.
+const updateFlag = "err2-update"
+
+// goldenDir is the directory of the golden files. It's relative to the
+// package directory, which is the working directory of go test.
+var goldenDir = "testdata"
+
+// Golden asserts that got is equal to the content of the golden file
+// testdata/<test name>/<name>.golden. If not it panics/errors (according the
+// current [Asserter]) with the auto-generated message that includes the line
+// diff of them. It's handy for the regression tests of the CLI outputs and the
+// error messages:
+//
+//	func TestUsage(t *testing.T) {
+//	     defer assert.PushTester(t)()
+//	     assert.Golden("usage", cmd.Usage())
+//
+// When the tests are run with the -err2-update flag, Golden writes got to the
+// golden file instead of comparing them:
+//
+//	go test -run TestUsage -err2-update
+//
+// Golden needs the testing context to know the test name, see [PushTester].
+//
+// Note that the flag isn't -update but -err2-update like the other err2 flags.
+// The flags of the imported packages share the namespace with your own flags,
+// and -update is a common name in the test packages, which would panic with
+// 'flag redefined' if the assert package registered it.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func Golden[T ~string | ~[]byte](name string, got T, a ...any) {
+	if !enabled {
+		return
+	}
+	golden(name, []byte(got), a)
+}
+
+func golden(name string, got []byte, a []any) {
+	t := tester()
+	if t == nil {
+		current().reportAssertionFault(1, assertionMsg()+msg(catalog.GoldenNoTester), a)
+		return
+	}
+	path := filepath.Join(goldenDir, filepath.FromSlash(t.Name()), name+".golden")
+	if updateGolden {
+		if err := writeGolden(path, got); err != nil {
+			defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.GoldenWrite), err)
+			current().reportAssertionFault(1, defMsg, a)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.GoldenRead), err)
+		current().reportAssertionFault(1, defMsg, a)
+		return
+	}
+	if !bytes.Equal(got, want) {
+		f := assertionMsg() + msg(catalog.GoldenDiff)
+		defMsg := fmt.Sprintf(f, path, diff.Lines(string(got), string(want)))
+		current().reportGotWant(1, defMsg, string(got), string(want), a)
+	}
+}
+
+func writeGolden(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o644)
+}
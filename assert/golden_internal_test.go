@@ -0,0 +1,31 @@
+package assert
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lainio/err2/internal/expect"
+)
+
+// TestGoldenUpdate cannot be parallel because it sets the default asserter and
+// the -err2-update flag.
+func TestGoldenUpdate(t *testing.T) {
+	defer SetDefault(SetDefault(Production))
+	UseTester(t, Plain) // errors instead of test failures
+
+	dir := t.TempDir()
+	defer func(d string) { goldenDir = d }(goldenDir)
+	goldenDir = dir
+	expect.That(t, flag.Set(updateFlag, "true") == nil)
+	defer flag.Set(updateFlag, "false")
+
+	Golden("new", "new content\n")
+	b, err := os.ReadFile(filepath.Join(dir, "TestGoldenUpdate", "new.golden"))
+	expect.That(t, err == nil, err)
+	expect.Equal(t, string(b), "new content\n")
+
+	expect.That(t, flag.Set(updateFlag, "false") == nil)
+	Golden("new", "new content\n") // compares now
+}
@@ -0,0 +1,37 @@
+package assert_test
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/assert"
+	"github.com/lainio/err2/internal/expect"
+)
+
+// TestGolden cannot be parallel because it sets the default asserter.
+func TestGolden(t *testing.T) {
+	defer assert.SetDefault(assert.SetDefault(assert.Production))
+	assert.UseTester(t, assert.Plain) // errors instead of test failures
+
+	golden := func(name, got string) (err error) {
+		defer err2.Handle(&err, err2.Noop)
+		assert.Golden(name, got)
+		return nil
+	}
+	expect.That(t, golden("usage", "hello\ngolden\n") == nil)
+
+	err := golden("usage", "hello\nsilver\n")
+	var ae *assert.AssertionError
+	expect.That(t, errors.As(err, &ae))
+	expect.Equal(t, ae.Kind, "Golden")
+	expect.That(t, strings.Contains(err.Error(),
+		filepath.Join("testdata", "TestGolden", "usage.golden")), err)
+	expect.That(t, strings.Contains(err.Error(), "- silver"), err)
+	expect.That(t, strings.Contains(err.Error(), "+ golden"), err)
+
+	err = golden("not-exist", "")
+	expect.That(t, errors.Is(err, assert.ErrAssertion))
+}
@@ -0,0 +1,124 @@
+package assert
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/lainio/err2/internal/x"
+)
+
+// Hook is a function that is called on every assertion violation before it's
+// reported by the current [Asserter]. Hooks are for the observability, e.g.,
+// logging or metrics, and they cannot prevent the reporting. See [AddHook].
+type Hook func(v Violation)
+
+type (
+	hookEntry struct {
+		id int
+		h  Hook
+	}
+	counterMap = map[string]*int64
+)
+
+var (
+	// hookMu protects hooks and hookID.
+	hookMu sync.RWMutex
+
+	// hooks are the registered Hooks in their registration order. The slice
+	// is copy-on-write, i.e., notify can call the hooks of its snapshot
+	// without the lock.
+	hooks []hookEntry
+
+	// hookID is the ID of the last registered Hook.
+	hookID int
+
+	// counters are the violation counts by their call sites.
+	counters = x.NewRWMap[counterMap]()
+)
+
+// AddHook registers the Hook and returns the function that removes it. The
+// hooks are called in their registration order, and they must be thread safe:
+//
+//	remove := assert.AddHook(func(v assert.Violation) {
+//	     log.Printf("%s:%d: %s", v.Frame.File, v.Frame.Line, v)
+//	})
+//	defer remove()
+//
+// Note that the hooks are called for the collected violations as well, see
+// [Collect].
+func AddHook(h Hook) (remove function) {
+	hookMu.Lock()
+	defer hookMu.Unlock()
+	hookID++
+	id := hookID
+	hooks = append(hooks[:len(hooks):len(hooks)], hookEntry{id: id, h: h})
+
+	return func() {
+		hookMu.Lock()
+		defer hookMu.Unlock()
+		hs := make([]hookEntry, 0, len(hooks))
+		for _, e := range hooks {
+			if e.id != id {
+				hs = append(hs, e)
+			}
+		}
+		hooks = hs
+	}
+}
+
+// Counts returns the snapshot of the violation counters. The keys are the call
+// sites of the assert functions in the format 'import/path.Func:line', and the
+// values tell how many times the assertion has been violated. The counters are
+// maintained for all of the asserters. Use the
+// [github.com/lainio/err2/assert/assertvar] package to publish them as expvar
+// variables.
+func Counts() map[string]int64 {
+	counts := make(map[string]int64, counters.Len())
+	counters.Rx(func(m counterMap) {
+		for site, n := range m {
+			counts[site] = atomic.LoadInt64(n)
+		}
+	})
+	return counts
+}
+
+// ResetCounts sets all of the violation counters to zero, see [Counts].
+func ResetCounts() {
+	counters.Tx(func(m counterMap) {
+		for site := range m {
+			delete(m, site)
+		}
+	})
+}
+
+// notify counts the violation and calls the hooks.
+func notify(v Violation) {
+	count(callSite(v))
+	hookMu.RLock()
+	hs := hooks
+	hookMu.RUnlock()
+	for _, e := range hs {
+		e.h(v) // outside of the lock, the hook can remove itself
+	}
+}
+
+func count(site string) {
+	n := counters.Get(site)
+	if n == nil {
+		counters.Tx(func(m counterMap) {
+			if n = m[site]; n == nil {
+				n = new(int64)
+				m[site] = n
+			}
+		})
+	}
+	atomic.AddInt64(n, 1)
+}
+
+// callSite returns the call site of the violation, e.g.,
+// 'example.com/pkg.Func:42'. The full function name is used because the last
+// elements of the import paths aren't unique.
+func callSite(v Violation) string {
+	return v.Frame.Function + ":" + strconv.Itoa(v.Frame.Line)
+}
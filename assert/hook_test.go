@@ -0,0 +1,81 @@
+package assert_test // Note!! Some tests here are related to line # of the file
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/assert"
+	"github.com/lainio/err2/internal/expect"
+)
+
+func TestAddHook(t *testing.T) {
+	t.Parallel()
+	const site = "github.com/lainio/err2/assert_test.TestAddHook.func2:32"
+	before := assert.Counts()[site]
+	var (
+		mu    sync.Mutex
+		kinds []string
+	)
+	remove := assert.AddHook(func(v assert.Violation) {
+		if v.Frame.Line != 32 { // only ours, tests run parallel
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		kinds = append(kinds, v.Kind)
+	})
+	sample := func(i int) (err error) {
+		defer err2.Handle(&err, err2.Noop)
+		defer assert.PushAsserter(assert.Plain)()
+
+		assert.Equal(i, 1)
+		return nil
+	}
+	expect.That(t, sample(1) == nil)
+	expect.That(t, sample(2) != nil)
+	expect.That(t, sample(3) != nil)
+	remove()
+	expect.That(t, sample(4) != nil)
+
+	expect.Equal(t, len(kinds), 2)
+	expect.Equal(t, kinds[0], "Equal")
+	expect.Equal(t, assert.Counts()[site]-before, 3)
+}
+
+func TestAddHookOrder(t *testing.T) {
+	t.Parallel()
+	var (
+		mu    sync.Mutex
+		order []int
+	)
+	const (
+		n  = 10
+		fn = "github.com/lainio/err2/assert_test.TestAddHookOrder.func2"
+	)
+	for i := 0; i < n; i++ {
+		i := i
+		remove := assert.AddHook(func(v assert.Violation) {
+			if v.Frame.Function != fn {
+				return // only ours, tests run parallel
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, i)
+		})
+		defer remove()
+	}
+	sample := func() (err error) {
+		defer err2.Handle(&err, err2.Noop)
+		defer assert.PushAsserter(assert.Plain)()
+
+		assert.That(false)
+		return nil
+	}
+	expect.That(t, sample() != nil)
+
+	expect.Equal(t, len(order), n)
+	for i := range order {
+		expect.Equal(t, order[i], i)
+	}
+}
@@ -0,0 +1,253 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lainio/err2/catalog"
+	"github.com/lainio/err2/internal/diff"
+)
+
+// JSONEq asserts that the JSON documents are semantically equal, i.e., the
+// key order and the whitespace don't matter. If they aren't equal it
+// panics/errors (according the current [Asserter]) with the auto-generated
+// message that lists the differences by their JSON paths:
+//
+//	assert.JSONEq(rec.Body.String(), `{"name": "alice", "age": 42}`)
+//
+// The output looks like:
+//
+//	assertion failure: JSON not equal, diff:
+//	  $.age: got 41, want 42
+//	  $.tags[1]: missing, want "admin"
+//	  $["a.b"]: unexpected, got true
+//
+// The keys that would make the path ambiguous, e.g. "a.b", are quoted. The
+// numbers are compared by their exact values, i.e., 1.0 equals 1, and the big
+// integers don't lose their precision. Like in the structural diffs, only the
+// first 20 differences are listed.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func JSONEq[T ~string | ~[]byte](got, want T, a ...any) {
+	if !enabled {
+		return
+	}
+	jsonEq([]byte(got), []byte(want), nil, a)
+}
+
+// JSONEqIgnore is like [JSONEq] but it skips the values of the ignored JSON
+// paths, e.g., timestamps and IDs. The paths use the same format as the
+// failure output, and the * matches any key or array index:
+//
+//	assert.JSONEqIgnore(got, want, []string{"$.id", "$.items[*].created"})
+//
+// The quoted keys are given in the same way, e.g. `$["a.b"].c`.
+//
+// Note that when [Plain] [Asserter] is used ([PushAsserter] or even
+// [SetDefault]), optional arguments are used to override the auto-generated
+// assert violation message.
+func JSONEqIgnore[T ~string | ~[]byte](got, want T, ignore []string, a ...any) {
+	if !enabled {
+		return
+	}
+	jsonEq([]byte(got), []byte(want), ignore, a)
+}
+
+func jsonEq(got, want []byte, ignore []string, a []any) {
+	g, err := decodeJSON(got)
+	if err != nil {
+		defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.InvalidJSON), "got", err)
+		current().reportAssertionFault(1, defMsg, a)
+		return
+	}
+	w, err := decodeJSON(want)
+	if err != nil {
+		defMsg := fmt.Sprintf(assertionMsg()+msg(catalog.InvalidJSON), "want", err)
+		current().reportAssertionFault(1, defMsg, a)
+		return
+	}
+	d := jsonDiff{}
+	for _, path := range ignore {
+		d.ignore = append(d.ignore, jsonPathRegexp(path))
+	}
+	d.diff("$", g, w)
+	if d.count == 0 {
+		return
+	}
+	defMsg := assertionMsg() + msg(catalog.JSONNotEqual) + d.String()
+	current().reportGotWant(1, defMsg, string(got), string(want), a)
+}
+
+// decodeJSON decodes the JSON document b. The numbers are decoded as
+// [json.Number]s that keep their precision.
+func decodeJSON(b []byte) (v any, err error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err = dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err = dec.Token(); err != io.EOF {
+		return nil, errors.New("invalid data after top-level value")
+	}
+	return v, nil
+}
+
+// jsonKey returns the JSON path of the key k of the object in path. The keys
+// that would make the path ambiguous are quoted, e.g. $["a.b"].
+func jsonKey(path, k string) string {
+	if k == "" || strings.ContainsAny(k, `.[]"*\`) {
+		return path + "[" + strconv.Quote(k) + "]"
+	}
+	return path + "." + k
+}
+
+// jsonNumberEq returns true if the JSON numbers have the same exact value.
+func jsonNumberEq(got, want json.Number) bool {
+	if got == want {
+		return true
+	}
+	g, gok := new(big.Rat).SetString(string(got))
+	w, wok := new(big.Rat).SetString(string(want))
+	return gok && wok && g.Cmp(w) == 0
+}
+
+// jsonPathRegexp returns the regexp of the ignored JSON path where the *
+// matches any key (quoted or not) or array index.
+func jsonPathRegexp(path string) *regexp.Regexp {
+	s := regexp.QuoteMeta(path)
+	s = strings.ReplaceAll(s, `\[\*\]`, `\[\d+\]`)
+	s = strings.ReplaceAll(s, `\.\*`, `(?:\.[^.\[]+|\["(?:[^"\\]|\\.)*"\])`)
+	re, err := compile("^" + s + "$")
+	if err != nil {
+		panic(err) // cannot happen, the path is quoted
+	}
+	return re
+}
+
+// jsonDiff collects the differences of the decoded JSON values by their
+// paths. Only the first [diff.MaxDiffs] lines are kept, but all of the
+// differences are counted.
+type jsonDiff struct {
+	ignore []*regexp.Regexp
+	lines  []string
+	count  int
+}
+
+func (d *jsonDiff) String() string {
+	s := "\n  " + strings.Join(d.lines, "\n  ")
+	if more := d.count - len(d.lines); more > 0 {
+		s += "\n  " + diff.More(more)
+	}
+	return s
+}
+
+func (d *jsonDiff) ignored(path string) bool {
+	for _, re := range d.ignore {
+		if re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *jsonDiff) diff(path string, got, want any) {
+	if d.ignored(path) {
+		return
+	}
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			d.gotWant(path, got, want)
+			return
+		}
+		for _, k := range sortedKeys(w) {
+			p := jsonKey(path, k)
+			if gv, found := g[k]; found {
+				d.diff(p, gv, w[k])
+			} else if !d.ignored(p) {
+				d.add(catalog.JSONPathMissing, p, w[k])
+			}
+		}
+		for _, k := range sortedKeys(g) {
+			p := jsonKey(path, k)
+			if _, found := w[k]; !found && !d.ignored(p) {
+				d.add(catalog.JSONPathUnexpected, p, g[k])
+			}
+		}
+	case []any:
+		g, ok := got.([]any)
+		if !ok {
+			d.gotWant(path, got, want)
+			return
+		}
+		for i := 0; i < len(g) || i < len(w); i++ {
+			p := path + "[" + strconv.Itoa(i) + "]"
+			switch {
+			case i >= len(g):
+				if !d.ignored(p) {
+					d.add(catalog.JSONPathMissing, p, w[i])
+				}
+			case i >= len(w):
+				if !d.ignored(p) {
+					d.add(catalog.JSONPathUnexpected, p, g[i])
+				}
+			default:
+				d.diff(p, g[i], w[i])
+			}
+		}
+	case json.Number:
+		if g, ok := got.(json.Number); !ok || !jsonNumberEq(g, w) {
+			d.gotWant(path, got, want)
+		}
+	default:
+		if !reflect.DeepEqual(got, want) {
+			d.gotWant(path, got, want)
+		}
+	}
+}
+
+func (d *jsonDiff) gotWant(path string, got, want any) {
+	d.report(msg(catalog.JSONPathGotWant), path, jsonValue(got), jsonValue(want))
+}
+
+func (d *jsonDiff) add(id catalog.ID, path string, v any) {
+	d.report(msg(id), path, jsonValue(v))
+}
+
+func (d *jsonDiff) report(format string, args ...any) {
+	d.count++
+	if len(d.lines) >= diff.MaxDiffs {
+		return
+	}
+	d.lines = append(d.lines, fmt.Sprintf(format, args...))
+}
+
+// jsonValue returns the compact JSON of the decoded value v.
+func jsonValue(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return diff.Truncate(string(b), diff.MaxValueLen)
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,85 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/lainio/err2/internal/diff"
+	"github.com/lainio/err2/internal/expect"
+)
+
+func jsonDiffOf(t *testing.T, got, want string, ignore ...string) jsonDiff {
+	t.Helper()
+	g, err := decodeJSON([]byte(got))
+	expect.That(t, err == nil)
+	w, err := decodeJSON([]byte(want))
+	expect.That(t, err == nil)
+	d := jsonDiff{}
+	for _, path := range ignore {
+		d.ignore = append(d.ignore, jsonPathRegexp(path))
+	}
+	d.diff("$", g, w)
+	return d
+}
+
+func TestJSONDiff(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		got, want string
+		ignore    []string
+		lines     []string
+	}{
+		{"equal numbers", `{"a": 1.0}`, `{"a": 1}`, nil, nil},
+		{"exponent", `[1e3]`, `[1000]`, nil, nil},
+		{"big ints", `{"id": 9007199254740993}`, `{"id": 9007199254740992}`, nil,
+			[]string{"$.id: got 9007199254740993, want 9007199254740992"}},
+		{"number vs string", `{"a": 1}`, `{"a": "1"}`, nil,
+			[]string{`$.a: got 1, want "1"`}},
+		{"dotted key", `{"a.b": 1, "a": {"b": 1}}`, `{"a": {"b": 1}}`, nil,
+			[]string{`$["a.b"]: unexpected, got 1`}},
+		{"bracket key", `{}`, `{"x[0]": {"y": 2}}`, nil,
+			[]string{`$["x[0]"]: missing, want {"y":2}`}},
+		{"empty key", `{"": 1}`, `{"": 2}`, nil,
+			[]string{`$[""]: got 1, want 2`}},
+		{"ignore quoted", `{"a.b": 1}`, `{"a.b": 2}`, []string{`$["a.b"]`}, nil},
+		{"ignore wildcard quoted", `{"a.b": 1, "c": 1}`, `{"a.b": 2, "c": 2}`,
+			[]string{`$.*`}, nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			d := jsonDiffOf(t, tt.got, tt.want, tt.ignore...)
+			expect.Equal(t, d.count, len(tt.lines))
+			expect.Equal(t, strings.Join(d.lines, "\n"), strings.Join(tt.lines, "\n"))
+		})
+	}
+}
+
+func TestJSONDiffMax(t *testing.T) {
+	t.Parallel()
+	const n = diff.MaxDiffs + 5
+	g := make([]string, n)
+	w := make([]string, n)
+	for i := range g {
+		g[i] = fmt.Sprint(i)
+		w[i] = fmt.Sprint(i + 1)
+	}
+	d := jsonDiffOf(t, "["+strings.Join(g, ",")+"]", "["+strings.Join(w, ",")+"]")
+	expect.Equal(t, d.count, n)
+	expect.Equal(t, len(d.lines), diff.MaxDiffs)
+	lines := strings.Split(d.String(), "\n")
+	expect.Equal(t, lines[len(lines)-1], "  ... and 5 more differences")
+}
+
+func TestDecodeJSON(t *testing.T) {
+	t.Parallel()
+	_, err := decodeJSON([]byte(`{"a": 1} x`))
+	expect.That(t, err != nil)
+	_, err = decodeJSON([]byte(`{"a": 1} {}`))
+	expect.That(t, err != nil)
+	_, err = decodeJSON([]byte(` {"a": 1} `))
+	expect.That(t, err == nil)
+}
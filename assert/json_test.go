@@ -0,0 +1,41 @@
+package assert_test // Note!! Some tests here are related to line # of the file
+
+import (
+	"fmt"
+
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/assert"
+)
+
+func ExampleJSONEq() {
+	sample := func(got string) (err error) {
+		defer err2.Handle(&err, "sample")
+
+		assert.JSONEq(got, `{"name": "alice", "age": 42, "tags": ["user", "admin"]}`)
+		return err
+	}
+	err := sample(`{"tags": ["user"], "age": 41, "name": "alice", "id": 7}`)
+	fmt.Printf("%v", err)
+	// Output: sample: json_test.go:14: assert_test.ExampleJSONEq.func1(): assertion failure: JSON not equal, diff:
+	//   $.age: got 41, want 42
+	//   $.tags[1]: missing, want "admin"
+	//   $.id: unexpected, got 7
+}
+
+func ExampleJSONEqIgnore() {
+	sample := func(got []byte) (err error) {
+		defer err2.Handle(&err, "sample")
+
+		want := []byte(`{"items": [{"id": 1, "name": "a"}, {"id": 2, "name": "b"}]}`)
+		assert.JSONEqIgnore(got, want, []string{"$.created", "$.items[*].id"})
+		return err
+	}
+	err := sample([]byte(`{"created": "2024-01-01",
+		"items": [{"id": 11, "name": "a"}, {"id": 12, "name": "b"}]}`))
+	fmt.Printf("%v\n", err)
+	err = sample([]byte(`{"items": [{"id": 11, "name": "a"}, {"id": 12}]}`))
+	fmt.Printf("%v", err)
+	// Output: <nil>
+	// sample: json_test.go:30: assert_test.ExampleJSONEqIgnore.func1(): assertion failure: JSON not equal, diff:
+	//   $.items[1].name: missing, want "b"
+}
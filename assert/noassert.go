@@ -0,0 +1,8 @@
+//go:build err2_noassert
+
+package assert
+
+// enabled is false when the err2_noassert build tag is set. Then the compiler
+// removes the condition checks, and the assert functions are empty stubs that
+// are inlined away.
+const enabled = false
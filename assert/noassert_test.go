@@ -0,0 +1,36 @@
+//go:build err2_noassert
+
+package assert_test
+
+import (
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/lainio/err2/assert"
+	"github.com/lainio/err2/internal/expect"
+)
+
+func TestNoAssert(t *testing.T) {
+	t.Parallel()
+	// the asserts don't check anything, and they cannot panic
+	assert.That(false)
+	assert.Equal(1, 2)
+	assert.NotNil((*int)(nil))
+	assert.SLen([]int{1}, 2)
+	assert.Error(nil)
+	assert.NotImplemented()
+	assert.Eventually(func() bool { return false }, 0, 0)
+	assert.Require(false)
+	assert.JSONEq(`{}`, `[]`)
+	assert.Golden("not-exist", "")
+	assert.EqualFunc(1, 2, func(a, b int) bool { return a == b })
+	defer assert.CheckInvariant(nil)()
+
+	// but the values are still returned
+	m := map[string]int{"a": 1}
+	expect.Equal(t, assert.MKeyExists(m, "a"), 1)
+	_, err := os.Open("not-exist")
+	pathErr := assert.ErrorAs[*fs.PathError](err)
+	expect.Equal(t, pathErr.Path, "not-exist")
+}
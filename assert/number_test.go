@@ -0,0 +1,73 @@
+package assert_test // Note!! Some tests here are related to line # of the file
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/assert"
+)
+
+func ExampleInDelta() {
+	sample := func(f float64) (err error) {
+		defer err2.Handle(&err, "sample")
+
+		assert.InDelta(f, 1.0, 0.5)  // OK
+		assert.InDelta(f, 1.0, 0.05) // Not OK
+		return err
+	}
+	err := sample(1.25)
+	fmt.Printf("%v", err)
+	// Output: sample: number_test.go:16: assert_test.ExampleInDelta.func1(): assertion failure: got '1.25', want '1' ± '0.05', difference '0.25'
+}
+
+func ExampleInDelta_nan() {
+	sample := func(f float64) (err error) {
+		defer err2.Handle(&err, "sample")
+
+		assert.InDelta(f, 1.0, 0.5) // Not OK
+		return err
+	}
+	err := sample(math.NaN())
+	fmt.Printf("%v", err)
+	// Output: sample: number_test.go:28: assert_test.ExampleInDelta_nan.func1(): assertion failure: got 'NaN', want '1', NaN cannot be compared
+}
+
+func ExampleInEpsilon() {
+	sample := func(f float64) (err error) {
+		defer err2.Handle(&err, "sample")
+
+		assert.InEpsilon(f, 100, 0.1)           // OK
+		assert.InEpsilon(math.Inf(1), 100, 0.1) // Not OK
+		return err
+	}
+	err := sample(95)
+	fmt.Printf("%v", err)
+	// Output: sample: number_test.go:41: assert_test.ExampleInEpsilon.func1(): assertion failure: got '+Inf', want '100', infinity cannot be within tolerance
+}
+
+func ExampleBetween() {
+	sample := func(i int) (err error) {
+		defer err2.Handle(&err, "sample")
+
+		assert.Between(i, 1, 10)          // OK
+		assert.BetweenExclusive(i, 1, 10) // Not OK
+		return err
+	}
+	err := sample(10)
+	fmt.Printf("%v", err)
+	// Output: sample: number_test.go:54: assert_test.ExampleBetween.func1(): assertion failure: got '10', want between ('1', '10')
+}
+
+func ExamplePositive() {
+	sample := func(i int) (err error) {
+		defer err2.Handle(&err, "sample")
+
+		assert.Negative(i) // OK
+		assert.Positive(i) // Not OK
+		return err
+	}
+	err := sample(-1)
+	fmt.Printf("%v", err)
+	// Output: sample: number_test.go:67: assert_test.ExamplePositive.func1(): assertion failure: got '-1', want > 0
+}
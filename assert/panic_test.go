@@ -0,0 +1,29 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lainio/err2/internal/expect"
+)
+
+func panicker() {
+	panic("boom")
+}
+
+func TestCatchPanic(t *testing.T) {
+	t.Parallel()
+	r, panicked, stack := catchPanic(panicker)
+	expect.That(t, panicked)
+	expect.That(t, r == "boom", r)
+	expect.That(t, strings.Contains(string(stack), "assert.panicker"), string(stack))
+
+	_, panicked, stack = catchPanic(func() {})
+	expect.ThatNot(t, panicked)
+	expect.That(t, stack == nil)
+
+	expect.Equal(t, prod.panicStack("msg", stack), "msg")
+	expect.Equal(t, dev.panicStack("msg", stack), "msg")
+	expect.Equal(t, dbg.panicStack("msg", []byte("stack")), "msg\npanic stack:\nstack")
+	expect.Equal(t, testFull.panicStack("msg", []byte("stack")), "msg\npanic stack:\nstack")
+}
@@ -0,0 +1,65 @@
+package assert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lainio/err2/internal/expect"
+)
+
+type stepClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *stepClock) Now() time.Time { return c.now }
+func (c *stepClock) Sleep(d time.Duration) {
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+}
+
+func TestPoll(t *testing.T) {
+	t.Parallel()
+	c := &stepClock{}
+	never := func() bool { return false }
+	ok, elapsed, polls := pollWith(c, never, time.Second, 300*time.Millisecond)
+	expect.ThatNot(t, ok)
+	expect.Equal(t, elapsed, time.Second) // doesn't overshoot the timeout
+	expect.Equal(t, polls, 5)
+	expect.Equal(t, c.sleeps[len(c.sleeps)-1], 100*time.Millisecond)
+}
+
+func TestEventuallyInvalidInterval(t *testing.T) {
+	t.Parallel()
+	defer PushAsserter(Plain)()
+	for _, interval := range []time.Duration{0, -time.Second} {
+		r, panicked, _ := catchPanic(func() {
+			Eventually(func() bool { return true }, time.Second, interval)
+		})
+		expect.That(t, panicked, "interval", interval)
+		err, _ := r.(error)
+		expect.That(t, err != nil)
+		expect.Equal(t, err.Error(), "assertion failure: invalid polling interval '"+
+			interval.String()+"'")
+	}
+}
+
+func TestPushClock(t *testing.T) {
+	t.Parallel()
+	global := clock()
+	for i := 0; i < 2; i++ {
+		t.Run("goroutine", func(t *testing.T) {
+			t.Parallel()
+			c := &stepClock{}
+			pop := PushClock(c)
+			expect.That(t, clock() == Clock(c))
+			inner := &stepClock{}
+			popInner := PushClock(inner)
+			expect.That(t, clock() == Clock(inner))
+			popInner()
+			expect.That(t, clock() == Clock(c))
+			pop()
+			expect.That(t, clock() == global)
+		})
+	}
+}
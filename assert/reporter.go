@@ -0,0 +1,193 @@
+package assert
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/lainio/err2/internal/x"
+)
+
+// Violation is a structured assertion violation that is given to the
+// [Reporter].
+type Violation struct {
+	// Kind is the name of the assert function, e.g., "Equal" or "SLen".
+	Kind string
+
+	// Message is the auto-generated assertion message without the caller
+	// info, e.g., "assertion failure: equal: got '1', want '2'".
+	Message string
+
+	// Got and Want are the compared values if the assert function has them.
+	// Otherwise they are nil.
+	Got, Want any
+
+	// Args are the optional arguments of the assert function call. If the
+	// first one is a string it's a format string for the rest.
+	Args []any
+
+	// Frame is the caller of the assert function, i.e., the location of the
+	// assertion violation.
+	Frame runtime.Frame
+
+	// extraInd is for the built-in asserters to calculate their stack levels.
+	extraInd int
+}
+
+// String returns the Message with the optional arguments in the same way as
+// the built-in asserters (except [Plain]) build the violation message.
+func (v Violation) String() string {
+	if len(v.Args) == 0 {
+		return v.Message
+	}
+	if format, ok := v.Args[0].(string); ok {
+		return fmt.Sprintf(v.Message+conCatErrStr+format, v.Args[1:]...)
+	}
+	s := fmt.Sprintln(append([]any{v.Message}, v.Args...)...)
+	return strings.TrimSuffix(s, "\n")
+}
+
+// Reporter is the interface of the [Asserter] implementations. The built-in
+// asserters like [Production] and [TestFull] implement it as well, see
+// [ReporterOf].
+type Reporter interface {
+	// Report reports the assertion violation. It can panic, e.g., with an
+	// error value that err2.Handle catches, or stop the test. If Report
+	// returns normally the execution continues after the assert function,
+	// which allows reporters that only count or log the violations.
+	Report(v Violation)
+}
+
+type reporterMap = map[Asserter]Reporter
+
+// reporters are the registered Reporters by their Asserter IDs.
+var reporters = x.NewRWMap[reporterMap]()
+
+// custom tells how many Reporters are registered. It's protected by mu.
+var custom Asserter
+
+// Register registers the Reporter and returns its new [Asserter] ID. The ID
+// can be used like the built-in asserters, e.g.:
+//
+//	metrics := assert.Register(myMetricsReporter{
+//	     next: assert.ReporterOf(assert.Production),
+//	})
+//	assert.SetDefault(metrics)
+//
+// Note that the Reporter must be thread safe.
+func Register(r Reporter) Asserter {
+	mu.Lock()
+	defer mu.Unlock()
+
+	id := Asserter(len(defAsserter)) + custom
+	custom++
+	reporters.Set(id, r)
+	return id
+}
+
+// ReporterOf returns the [Reporter] of the [Asserter]. It returns nil if the
+// Asserter isn't a built-in or registered one. ReporterOf allows you to wrap
+// the built-in asserters with your own Reporter, see [Register].
+func ReporterOf(a Asserter) Reporter {
+	if int(a) < len(defAsserter) {
+		return defAsserter[a]
+	}
+	return reporters.Get(a)
+}
+
+// asserterOf returns the asserter of the Asserter ID. The registered Reporters
+// are presented by the asserterCustom flag and their ID. It panics if the ID
+// isn't a built-in or a registered one.
+func asserterOf(a Asserter) asserter {
+	if int(a) < len(defAsserter) {
+		return defAsserter[a]
+	}
+	if reporters.Get(a) == nil {
+		panic(fmt.Sprintf("assert: unregistered Asserter %d", a))
+	}
+	return asserterCustom | asserter(a)<<customShift
+}
+
+// mustBeAsserter panics if the Asserter ID isn't a built-in or a registered
+// one. The setters call it to panic at the bad call and not at the next assert.
+func mustBeAsserter(a Asserter) {
+	_ = asserterOf(a)
+}
+
+// id returns the Asserter ID of the asserter. It panics if the asserter isn't
+// one of the built-in or registered ones.
+func (asserter asserter) id() Asserter {
+	if asserter.isCustom() {
+		return Asserter(asserter >> customShift)
+	}
+	for i, a := range defAsserter {
+		if a == asserter {
+			return Asserter(i)
+		}
+	}
+	panic(fmt.Sprintf("assert: unknown asserter %#x", uint32(asserter)))
+}
+
+// newViolation returns the violation for the assert function that is
+// extraInd frames from the caller of newViolation's caller.
+func newViolation(extraInd int, msg string, got, want any, a []any) Violation {
+	// 0 = newViolation, 1 = reportAssertionFault, 2 = assert function
+	const assertFn = 2
+	v := Violation{
+		Message:  msg,
+		Got:      got,
+		Want:     want,
+		Args:     a,
+		extraInd: extraInd,
+	}
+	if pc, _, _, ok := runtime.Caller(assertFn + extraInd); ok {
+		v.Kind = kind(runtime.FuncForPC(pc).Name())
+	}
+	if pc, file, line, ok := runtime.Caller(assertFn + extraInd + 1); ok {
+		v.Frame = runtime.Frame{
+			PC:       pc,
+			Func:     runtime.FuncForPC(pc),
+			Function: runtime.FuncForPC(pc).Name(),
+			File:     file,
+			Line:     line,
+		}
+	}
+	return v
+}
+
+// reportDepth is the depth of the violation's frame from the built-in
+// asserter's Report when the assert function calls the report function
+// directly: Report, report, reportAssertionFault, assert function, caller.
+const reportDepth = 4
+
+// frameDepth returns how many frames the frame is from the caller of
+// frameDepth. The frame is found by its function and line.
+func frameDepth(frame runtime.Frame) (depth int, found bool) {
+	if frame.Function == "" {
+		return 0, false
+	}
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(2, pcs) // skip runtime.Callers and frameDepth
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		f, more := frames.Next()
+		if f.Function == frame.Function && f.Line == frame.Line {
+			return depth, true
+		}
+		if !more {
+			return 0, false
+		}
+		depth++
+	}
+}
+
+// kind returns the assert function name from its full name, e.g.,
+// 'github.com/lainio/err2/assert.Equal[...]' -> 'Equal'.
+func kind(fullName string) string {
+	name := strings.TrimPrefix(filepath.Base(fullName), "assert.")
+	if i := strings.IndexAny(name, ".["); i != -1 {
+		name = name[:i]
+	}
+	return name
+}
@@ -0,0 +1,87 @@
+package assert
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/lainio/err2/internal/expect"
+)
+
+type depthRecorder struct {
+	sync.Mutex
+	extra []int
+	want  []int
+}
+
+func (r *depthRecorder) Report(v Violation) {
+	depth, found := frameDepth(v.Frame)
+	r.Lock()
+	defer r.Unlock()
+	if !found {
+		depth = reportDepth - 1
+	}
+	r.extra = append(r.extra, depth-reportDepth)
+	r.want = append(r.want, v.extraInd)
+}
+
+// wrapper is a Reporter that wraps another one, i.e., it adds a frame.
+type wrapper struct{ next Reporter }
+
+func (w wrapper) Report(v Violation) { w.next.Report(v) }
+
+func TestFrameDepth(t *testing.T) {
+	t.Parallel()
+	r := &depthRecorder{}
+	func() {
+		defer PushAsserter(Register(r))()
+		That(false) // extraInd 0
+		Equal(1, 2) // extraInd 1
+		SLen([]int{}, 1)
+	}()
+	expect.Equal(t, len(r.extra), 3)
+	for i := range r.extra {
+		expect.Equal(t, r.extra[i], r.want[i])
+	}
+
+	w := &depthRecorder{}
+	func() {
+		defer PushAsserter(Register(wrapper{next: w}))()
+		Equal(1, 2)
+	}()
+	expect.Equal(t, w.extra[0], w.want[0]+1) // the wrapper's frame
+}
+
+func TestAsserterOfUnregistered(t *testing.T) {
+	t.Parallel()
+	r, panicked, _ := catchPanic(func() {
+		asserterOf(1 << 20)
+	})
+	expect.That(t, panicked)
+	expect.Equal(t, r.(string), "assert: unregistered Asserter 1048576")
+}
+
+func TestSettersUnregistered(t *testing.T) {
+	t.Parallel()
+	const unregistered = Asserter(1 << 20)
+	setters := map[string]func(){
+		"SetDefault":        func() { SetDefault(unregistered) },
+		"PushAsserter":      func() { PushAsserter(unregistered)() },
+		"SetPackageDefault": func() { SetPackageDefault("example.com/pkg", unregistered) },
+		"SetContracts":      func() { SetContracts(unregistered, false) },
+	}
+	for name, set := range setters {
+		r, panicked, _ := catchPanic(set)
+		expect.That(t, panicked, name)
+		expect.Equal(t, r.(string), "assert: unregistered Asserter 1048576")
+	}
+	expect.Equal(t, pkgDefaults.Get("example.com/pkg"), 0)
+	expect.Equal(t, contractsOff.Get(unregistered), false)
+}
+
+// TestCustomAsserterString cannot be parallel because it sets the default
+// asserter.
+func TestCustomAsserterString(t *testing.T) {
+	defer SetDefault(SetDefault(Register(&depthRecorder{})))
+	expect.Equal(t, asserterFlag.String(), "Custom")
+	expect.That(t, asserterFlag.Get() == "Custom")
+}
@@ -0,0 +1,131 @@
+package assert_test // Note!! Some tests here are related to line # of the file
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/assert"
+	"github.com/lainio/err2/internal/expect"
+)
+
+// recorder is a Reporter that records the violations and lets the execution
+// continue.
+type recorder struct {
+	sync.Mutex
+	violations []assert.Violation
+}
+
+func (r *recorder) Report(v assert.Violation) {
+	r.Lock()
+	defer r.Unlock()
+	r.violations = append(r.violations, v)
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+	r := &recorder{}
+	id := assert.Register(r)
+	expect.That(t, assert.ReporterOf(id) == r)
+
+	func() {
+		defer assert.PushAsserter(id)()
+
+		assert.Equal(1, 2, "number %d", 1)
+		assert.That(false)
+		assert.SLen([]int{1}, 2)
+	}()
+
+	expect.Equal(t, len(r.violations), 3)
+	v := r.violations[0]
+	expect.Equal(t, v.Kind, "Equal")
+	expect.That(t, v.Got == 1, v.Got)
+	expect.That(t, v.Want == 2, v.Want)
+	expect.Equal(t, v.Message, "assertion failure: equal: got '1', want '2'")
+	expect.Equal(t, v.String(), v.Message+": number 1")
+	expect.Equal(t, filepath.Base(v.Frame.File), "reporter_test.go")
+	expect.Equal(t, v.Frame.Line, 36)
+
+	v = r.violations[1]
+	expect.Equal(t, v.Kind, "That")
+	expect.That(t, v.Got == nil, v.Got)
+	expect.Equal(t, v.Frame.Line, 37)
+
+	v = r.violations[2]
+	expect.Equal(t, v.Kind, "SLen")
+	expect.That(t, v.Got == 1, v.Got)
+	expect.That(t, v.Want == 2, v.Want)
+}
+
+// counter counts the violations, and then lets the next Reporter to handle
+// them.
+type counter struct {
+	sync.Mutex
+	count int
+	next  assert.Reporter
+}
+
+func (c *counter) Report(v assert.Violation) {
+	c.Lock()
+	c.count++
+	c.Unlock()
+	c.next.Report(v)
+}
+
+func TestReporterOf(t *testing.T) {
+	t.Parallel()
+	c := &counter{next: assert.ReporterOf(assert.Plain)}
+	id := assert.Register(c)
+
+	sample := func() (err error) {
+		defer err2.Handle(&err, err2.Noop)
+		defer assert.PushAsserter(id)()
+
+		assert.NotEmpty("", "name cannot be empty")
+		return nil
+	}
+	err := sample()
+	expect.Equal(t, c.count, 1)
+	expect.Equal(t, err.Error(), "name cannot be empty")
+	expect.That(t, assert.ReporterOf(assert.Asserter(1000)) == nil)
+}
+
+func ExampleRegister() {
+	id := assert.Register(reporterFunc(func(v assert.Violation) {
+		panic(fmt.Errorf(`{"kind": %q, "line": %d}`, v.Kind, v.Frame.Line))
+	}))
+	sample := func() (err error) {
+		defer err2.Handle(&err, err2.Noop)
+		defer assert.PushAsserter(id)()
+
+		assert.Greater(1, 2)
+		return nil
+	}
+	err := sample()
+	fmt.Println(err)
+	// Output: {"kind": "Greater", "line": 103}
+}
+
+type reporterFunc func(v assert.Violation)
+
+func (f reporterFunc) Report(v assert.Violation) { f(v) }
+
+func TestSetContractsCustom(t *testing.T) {
+	t.Parallel()
+	r := &recorder{}
+	id := assert.Register(r)
+	for id < 40 { // beyond the bits of uint32
+		id = assert.Register(r)
+	}
+	defer assert.PushAsserter(id)()
+
+	expect.That(t, assert.SetContracts(id, false))
+	assert.Require(false)
+	expect.Equal(t, len(r.violations), 0)
+
+	expect.ThatNot(t, assert.SetContracts(id, true))
+	assert.Require(false)
+	expect.Equal(t, len(r.violations), 1)
+}
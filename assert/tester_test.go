@@ -0,0 +1,58 @@
+package assert
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/lainio/err2/internal/expect"
+)
+
+func hasTester(t testing.TB) (found bool) {
+	testers.Rx(func(m testersMap) {
+		for _, tt := range m {
+			if tt == t {
+				found = true
+			}
+		}
+	})
+	return found
+}
+
+// TestUseTester cannot be parallel because UseTester sets the default
+// asserter, which we restore for the other tests and examples.
+func TestUseTester(t *testing.T) {
+	defer SetDefault(SetDefault(Production))
+
+	var (
+		subT  testing.TB
+		stale = make(chan struct{})
+		done  = make(chan testing.TB)
+	)
+	t.Run("sub", func(t *testing.T) {
+		UseTester(t)
+		subT = t
+		expect.That(t, tester() == t)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		Go(func() {
+			defer wg.Done()
+			expect.That(t, tester() == t)
+			Go(func() { // nested
+				<-stale
+				done <- tester()
+			})
+		})
+		wg.Wait()
+	})
+	expect.ThatNot(t, hasTester(subT))
+
+	close(stale)
+	expect.That(t, <-done == nil) // stale goroutine has no tester anymore
+	expect.ThatNot(t, hasTester(subT))
+
+	Go(func() { // no tester, plain goroutine
+		done <- tester()
+	})
+	expect.That(t, <-done == nil)
+}
@@ -0,0 +1,92 @@
+package assert
+
+import (
+	"sort"
+	"strings"
+)
+
+// ValidationErrors is the error type of [Validate]. It maps the field names to
+// their assertion violation messages. The violations outside of the
+// [Validation.Field] calls have the empty field name.
+type ValidationErrors map[string][]string
+
+// Error returns the violations sorted by the field names, one per line, e.g.,
+// 'pool_name: pool name cannot be empty'.
+func (ve ValidationErrors) Error() string {
+	fields := make([]string, 0, len(ve))
+	for field := range ve {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var lines []string
+	for _, field := range fields {
+		for _, m := range ve[field] {
+			if field != "" {
+				m = field + conCatErrStr + m
+			}
+			lines = append(lines, m)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Is returns true for the [ErrAssertion].
+func (ve ValidationErrors) Is(target error) bool {
+	return target == ErrAssertion
+}
+
+// Validation is the scope of [Validate] that maps the assertion violations to
+// the fields.
+type Validation struct {
+	errs ValidationErrors
+}
+
+// Field calls the function f and adds all of its assertion violations to the
+// field name:
+//
+//	v.Field("wallet", func() {
+//	     assert.NotEmpty(c.Wallet, "wallet cannot be empty")
+//	})
+func (v *Validation) Field(name string, f func()) {
+	v.add(name, Collect(f))
+}
+
+func (v *Validation) add(name string, err error) {
+	violations, _ := err.(Violations)
+	for _, e := range violations {
+		v.errs[name] = append(v.errs[name], e.Error())
+	}
+}
+
+// Validate calls the function f with the [Plain] [Asserter], i.e., the
+// violation messages are the optional arguments of the assert functions. That
+// holds in the unit testing mode ([PushTester]) as well. It returns all of the
+// violations as a [ValidationErrors] error, or nil if there are none:
+//
+//	func (c *Cmd) Validate() error {
+//	     return assert.Validate(func(v *assert.Validation) {
+//	          v.Field("pool_name", func() {
+//	               assert.NotEmpty(c.PoolName, "pool name cannot be empty")
+//	          })
+//	          v.Field("port", func() {
+//	               assert.Between(c.Port, 1, 65535, "port must be 1..65535")
+//	          })
+//	     })
+//	}
+//
+// The ValidationErrors is a normal error value, i.e., you can use it with
+// err2.Handle, err2.Catch and try.To, and find it with [errors.As]. See
+// [Collect] for the details of the collecting mode.
+func Validate(f func(v *Validation)) error {
+	defer pushAsserter(Plain)() // Plain even in the unit testing mode
+
+	v := &Validation{errs: make(ValidationErrors)}
+	v.add("", Collect(func() {
+		f(v)
+	}))
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
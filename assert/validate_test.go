@@ -0,0 +1,79 @@
+package assert_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/assert"
+	"github.com/lainio/err2/internal/expect"
+)
+
+type cmd struct {
+	PoolName string
+	Wallet   string
+	Port     int
+}
+
+func (c cmd) Validate() error {
+	return assert.Validate(func(v *assert.Validation) {
+		v.Field("pool_name", func() {
+			assert.NotEmpty(c.PoolName, "pool name cannot be empty")
+		})
+		v.Field("wallet", func() {
+			assert.NotEmpty(c.Wallet, "wallet cannot be empty")
+			assert.Longer(c.Wallet, 3, "wallet name is too short")
+		})
+		v.Field("port", func() {
+			assert.Between(c.Port, 1, 65535, "port must be 1..65535")
+		})
+		assert.That(c.PoolName != c.Wallet, "pool and wallet must differ")
+	})
+}
+
+func ExampleValidate() {
+	run := func(c cmd) (err error) {
+		defer err2.Handle(&err, "run")
+
+		return c.Validate()
+	}
+	err := run(cmd{Port: 80})
+	fmt.Printf("%v\n", err)
+	err = run(cmd{PoolName: "pool", Wallet: "wallet", Port: 80})
+	fmt.Printf("%v", err)
+	// Output: run: pool and wallet must differ
+	// pool_name: pool name cannot be empty
+	// wallet: wallet cannot be empty
+	// wallet: wallet name is too short
+	// <nil>
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	catch := func(c cmd) (err error) {
+		defer err2.Handle(&err, err2.Noop)
+
+		panic(c.Validate()) // like try.To throws
+	}
+	err := catch(cmd{PoolName: "p", Port: 70000})
+	expect.That(t, errors.Is(err, assert.ErrAssertion))
+
+	var ve assert.ValidationErrors
+	expect.That(t, errors.As(err, &ve))
+	expect.Equal(t, len(ve), 2)
+	expect.Equal(t, len(ve["wallet"]), 2)
+	expect.Equal(t, ve["port"][0], "port must be 1..65535")
+}
+
+// TestValidateTester cannot be parallel because it sets the default asserter.
+func TestValidateTester(t *testing.T) {
+	defer assert.SetDefault(assert.SetDefault(assert.Production))
+	defer assert.PushTester(t)()
+
+	err := cmd{PoolName: "p", Port: 70000}.Validate()
+	var ve assert.ValidationErrors
+	expect.That(t, errors.As(err, &ve))
+	expect.Equal(t, len(ve), 2)
+	expect.Equal(t, ve["port"][0], "port must be 1..65535")
+}
@@ -0,0 +1,34 @@
+package err2
+
+import (
+	"github.com/lainio/err2/catalog"
+	msgstore "github.com/lainio/err2/internal/catalog"
+)
+
+// SetCatalog sets the current message catalog for the err2 and assert
+// packages. The default [catalog.English] gives the messages as they have
+// always been. The catalog is consulted for the built-in assertion messages
+// and for the automatic error annotations produced by the current formatter
+// (see [SetFormatter]).
+//
+// The following sets a catalog where only some of the messages are
+// translated, and the rest are taken from [catalog.English]:
+//
+//	err2.SetCatalog(catalog.Messages{
+//	     catalog.Assertion: "väittämä ei pidä",
+//	     catalog.Equal:     "yhtäsuuruus",
+//	})
+//
+// You can make your own implementations of catalogs, e.g., to translate the
+// automatic annotations. See more information in catalog package.
+//
+// The nil resets the default [catalog.English].
+func SetCatalog(c catalog.Interface) {
+	msgstore.SetCatalog(c)
+}
+
+// Catalog returns the current message catalog. See more information from
+// [SetCatalog] and [catalog] package.
+func Catalog() catalog.Interface {
+	return msgstore.Catalog()
+}
@@ -0,0 +1,228 @@
+// Package catalog implements message catalogs for err2 and assert packages.
+// The catalogs allow localization of the automatic error annotations and the
+// built-in assertion messages. See more information from err2.SetCatalog.
+package catalog
+
+// ID is an identifier of the built-in message. Every built-in assertion
+// message has its own ID.
+type ID int
+
+// IDs of the built-in messages. The comments show the [English] (default)
+// messages. Note that some of the messages are format strings, and they must
+// keep the same verbs in the same order.
+const (
+	Assertion               ID = iota // assertion failure
+	Equal                             // equal
+	NotEqual                          // not equal
+	Length                            // length
+	GotWant                           // : got '%v', want '%v'
+	GotWantNotEqual                   // : got '%v' want (!= '%v')
+	GotWantNotDeep                    // : got '%v', want (!= '%v')
+	GotWantLonger                     // : got '%v', should be longer than '%v'
+	GotWantShorter                    // : got '%v', should be shorter than '%v'
+	GotWantGreater                    // : got '%v', want <= '%v'
+	GotWantLess                       // : got '%v', want >= '%v'
+	GotWantZero                       // : got '%v', want (== '0')
+	GotWantNotZero                    // : got '%v', want (!= 0)
+	NotImplemented                    // not implemented
+	KeyNotExist                       // : key '%v' doesn't exist
+	MissingError                      // missing error
+	ShouldBe                          // %s should be %s
+	ShouldNotBe                       // %s should not be %s
+	TypePointer                       // pointer
+	TypeInterface                     // interface
+	TypeSlice                         // slice
+	TypeChannel                       // channel
+	TypeMap                           // map
+	TypeString                        // string
+	Nil                               // nil
+	Empty                             // empty
+	AssertionCatching                 // assertion catching
+	AssertionFaultAt                  // Assertion Fault at:
+	GotWantDiff                       // : got and want differ:\n%s
+	ErrorIsNot                        // : error chain doesn't include '%v'
+	ErrorAsNot                        // : error chain doesn't include type '%v'
+	ErrorNotContains                  // : error message '%v' doesn't contain '%v'
+	ErrorChain                        // error chain:
+	NoPanic                           // : function should panic
+	Panic                             // : unexpected panic: '%v'
+	PanicNotMatch                     // : panic value '%v' doesn't match
+	NotEventually                     // : condition not satisfied in %v after %d polls
+	NotNever                          // : condition satisfied in %v after %d polls
+	NotEventuallyEqual                // : got '%v', want '%v' in %v after %d polls
+	SliceNotContains                  // : slice doesn't contain '%v'
+	SliceContains                     // : slice contains '%v' at index %d
+	NotSubset                         // : slice doesn't contain subset element '%v' at index %d
+	ElementsNotMatch                  // : elements differ: extra %v, missing %v
+	NotSorted                         // : not sorted at index %d: '%v' is after '%v'
+	NotUnique                         // : duplicate '%v' at indexes %d and %d
+	KeyExists                         // : key '%v' exists
+	ValueNotEqual                     // : value of key '%v': got '%v', want '%v'
+	GotWantDelta                      // : got '%v', want '%v' ± '%v', difference '%v'
+	GotWantEpsilon                    // : got '%v', want '%v' ± '%v' relative, relative error '%v'
+	GotWantBetween                    // : got '%v', want between ['%v', '%v']
+	GotWantBetweenExclusive           // : got '%v', want between ('%v', '%v')
+	GotWantPositive                   // : got '%v', want > 0
+	GotWantNegative                   // : got '%v', want < 0
+	GotWantNaN                        // : got '%v', want '%v', NaN cannot be compared
+	GotWantInf                        // : got '%v', want '%v', infinity cannot be within tolerance
+	GotWantRelZero                    // : got '%v', want '0', relative error is undefined
+	InvalidTolerance                  // : invalid tolerance '%v'
+	StrNotContains                    // : string '%v' doesn't contain '%v'
+	NoPrefix                          // : string '%v' doesn't have prefix '%v'
+	NoSuffix                          // : string '%v' doesn't have suffix '%v'
+	NotMatch                          // : string '%v' doesn't match '%v'
+	InvalidPattern                    // : invalid regexp '%v': %v
+	Violations                        // %d assertion violations:
+	Precondition                      // precondition violated
+	Postcondition                     // postcondition violated
+	InvariantEntry                    // invariant violated on entry
+	InvariantExit                     // invariant violated on exit
+	JSONNotEqual                      // : JSON not equal, diff:
+	JSONPathGotWant                   // %v: got %v, want %v
+	JSONPathMissing                   // %v: missing, want %v
+	JSONPathUnexpected                // %v: unexpected, got %v
+	InvalidJSON                       // : invalid %v JSON: %v
+	GoldenDiff                        // : golden file '%v' differs, update it with -err2-update:\n%s
+	GoldenRead                        // : cannot read golden file: %v
+	GoldenWrite                       // : cannot write golden file: %v
+	GoldenNoTester                    // : golden file needs the testing context, see PushTester
+	PanicStack                        // panic stack:
+	InvalidInterval                   // : invalid polling interval '%v'
+)
+
+// Interface is a message catalog interface. The implementers are used for
+// built-in assertion messages and for automatic error annotations. See more
+// information from err2.SetCatalog.
+type Interface interface {
+	// Message returns the message for the ID.
+	Message(id ID) string
+
+	// Annotation returns the automatic error annotation for the function.
+	// The annotation argument is the output of the current formatter, and
+	// the funcName is the function name as it is in the call stack.
+	Annotation(funcName, annotation string) string
+}
+
+var english = map[ID]string{
+	Assertion:               "assertion failure",
+	Equal:                   "equal",
+	NotEqual:                "not equal",
+	Length:                  "length",
+	GotWant:                 ": got '%v', want '%v'",
+	GotWantNotEqual:         ": got '%v' want (!= '%v')",
+	GotWantNotDeep:          ": got '%v', want (!= '%v')",
+	GotWantLonger:           ": got '%v', should be longer than '%v'",
+	GotWantShorter:          ": got '%v', should be shorter than '%v'",
+	GotWantGreater:          ": got '%v', want <= '%v'",
+	GotWantLess:             ": got '%v', want >= '%v'",
+	GotWantZero:             ": got '%v', want (== '0')",
+	GotWantNotZero:          ": got '%v', want (!= 0)",
+	NotImplemented:          "not implemented",
+	KeyNotExist:             ": key '%v' doesn't exist",
+	MissingError:            "missing error",
+	ShouldBe:                "%s should be %s",
+	ShouldNotBe:             "%s should not be %s",
+	TypePointer:             "pointer",
+	TypeInterface:           "interface",
+	TypeSlice:               "slice",
+	TypeChannel:             "channel",
+	TypeMap:                 "map",
+	TypeString:              "string",
+	Nil:                     "nil",
+	Empty:                   "empty",
+	AssertionCatching:       "assertion catching",
+	AssertionFaultAt:        "Assertion Fault at:",
+	GotWantDiff:             ": got and want differ:\n%s",
+	ErrorIsNot:              ": error chain doesn't include '%v'",
+	ErrorAsNot:              ": error chain doesn't include type '%v'",
+	ErrorNotContains:        ": error message '%v' doesn't contain '%v'",
+	ErrorChain:              "error chain:",
+	NoPanic:                 ": function should panic",
+	Panic:                   ": unexpected panic: '%v'",
+	PanicNotMatch:           ": panic value '%v' doesn't match",
+	NotEventually:           ": condition not satisfied in %v after %d polls",
+	NotNever:                ": condition satisfied in %v after %d polls",
+	NotEventuallyEqual:      ": got '%v', want '%v' in %v after %d polls",
+	SliceNotContains:        ": slice doesn't contain '%v'",
+	SliceContains:           ": slice contains '%v' at index %d",
+	NotSubset:               ": slice doesn't contain subset element '%v' at index %d",
+	ElementsNotMatch:        ": elements differ: extra %v, missing %v",
+	NotSorted:               ": not sorted at index %d: '%v' is after '%v'",
+	NotUnique:               ": duplicate '%v' at indexes %d and %d",
+	KeyExists:               ": key '%v' exists",
+	ValueNotEqual:           ": value of key '%v': got '%v', want '%v'",
+	GotWantDelta:            ": got '%v', want '%v' ± '%v', difference '%v'",
+	GotWantEpsilon:          ": got '%v', want '%v' ± '%v' relative, relative error '%v'",
+	GotWantBetween:          ": got '%v', want between ['%v', '%v']",
+	GotWantBetweenExclusive: ": got '%v', want between ('%v', '%v')",
+	GotWantPositive:         ": got '%v', want > 0",
+	GotWantNegative:         ": got '%v', want < 0",
+	GotWantNaN:              ": got '%v', want '%v', NaN cannot be compared",
+	GotWantInf:              ": got '%v', want '%v', infinity cannot be within tolerance",
+	GotWantRelZero:          ": got '%v', want '0', relative error is undefined",
+	InvalidTolerance:        ": invalid tolerance '%v'",
+	StrNotContains:          ": string '%v' doesn't contain '%v'",
+	NoPrefix:                ": string '%v' doesn't have prefix '%v'",
+	NoSuffix:                ": string '%v' doesn't have suffix '%v'",
+	NotMatch:                ": string '%v' doesn't match '%v'",
+	InvalidPattern:          ": invalid regexp '%v': %v",
+	Violations:              "%d assertion violations:",
+	Precondition:            "precondition violated",
+	Postcondition:           "postcondition violated",
+	InvariantEntry:          "invariant violated on entry",
+	InvariantExit:           "invariant violated on exit",
+	JSONNotEqual:            ": JSON not equal, diff:",
+	JSONPathGotWant:         "%v: got %v, want %v",
+	JSONPathMissing:         "%v: missing, want %v",
+	JSONPathUnexpected:      "%v: unexpected, got %v",
+	InvalidJSON:             ": invalid %v JSON: %v",
+	GoldenDiff:              ": golden file '%v' differs, update it with -err2-update:\n%s",
+	GoldenRead:              ": cannot read golden file: %v",
+	GoldenWrite:             ": cannot write golden file: %v",
+	GoldenNoTester:          ": golden file needs the testing context, see PushTester",
+	PanicStack:              "panic stack:",
+	InvalidInterval:         ": invalid polling interval '%v'",
+}
+
+// English is the default message catalog. It gives the messages as they are
+// documented in the [ID] constants, and it keeps the automatic annotations as
+// they are.
+//
+// You can embed English to your own catalog and override only the methods you
+// need.
+type English struct{}
+
+// Message returns the English message for the ID.
+func (English) Message(id ID) string {
+	return english[id]
+}
+
+// Annotation returns the annotation as it is.
+func (English) Annotation(_, annotation string) string {
+	return annotation
+}
+
+// Messages is a helper type to build a catalog from a map. The missing
+// messages are taken from the [English] catalog, and the automatic annotations
+// are kept as they are:
+//
+//	err2.SetCatalog(catalog.Messages{
+//	     catalog.Assertion: "väittämä ei pidä",
+//	     catalog.Equal:     "yhtäsuuruus",
+//	})
+type Messages map[ID]string
+
+// Message returns the message for the ID or the English message if it's
+// missing.
+func (m Messages) Message(id ID) string {
+	if msg, found := m[id]; found {
+		return msg
+	}
+	return English{}.Message(id)
+}
+
+// Annotation returns the annotation as it is.
+func (Messages) Annotation(_, annotation string) string {
+	return annotation
+}
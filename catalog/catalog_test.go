@@ -0,0 +1,68 @@
+package catalog_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/assert"
+	"github.com/lainio/err2/catalog"
+)
+
+type finnish struct {
+	catalog.Messages
+}
+
+func (finnish) Annotation(funcName, annotation string) string {
+	if strings.HasSuffix(funcName, "CopyFile") {
+		return "tiedoston kopiointi"
+	}
+	return annotation
+}
+
+func ExampleMessages() {
+	defer err2.SetCatalog(err2.Catalog())
+	err2.SetCatalog(catalog.Messages{
+		catalog.Assertion: "väittämä ei pidä",
+		catalog.Equal:     "yhtäsuuruus",
+		catalog.GotWant:   ": saatiin '%v', haluttiin '%v'",
+	})
+	defer assert.PushAsserter(assert.Plain)()
+
+	sample := func(b int) (err error) {
+		defer err2.Handle(&err, "esimerkki")
+
+		assert.Equal(b, 1)
+		return nil
+	}
+	fmt.Println(sample(2))
+	// Output: esimerkki: väittämä ei pidä: yhtäsuuruus: saatiin '2', haluttiin '1'
+}
+
+func ExampleInterface() {
+	defer err2.SetCatalog(err2.Catalog())
+	err2.SetCatalog(finnish{catalog.Messages{
+		catalog.Assertion:   "väittämä ei pidä",
+		catalog.ShouldNotBe: "%s ei saa olla %s",
+		catalog.TypeString:  "merkkijono",
+		catalog.Empty:       "tyhjä",
+	}})
+	defer assert.PushAsserter(assert.Plain)()
+
+	sample := func(s string) (err error) {
+		defer err2.Handle(&err, "esimerkki")
+
+		assert.NotEmpty(s)
+		return nil
+	}
+	fmt.Println(sample(""))
+	fmt.Println(err2.Catalog().Annotation("CopyFile", "copy file"))
+	// Output:
+	// esimerkki: väittämä ei pidä: merkkijono ei saa olla tyhjä
+	// tiedoston kopiointi
+}
+
+func ExampleEnglish() {
+	fmt.Println(catalog.English{}.Message(catalog.GotWant))
+	// Output: : got '%v', want '%v'
+}
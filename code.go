@@ -0,0 +1,74 @@
+package err2
+
+import (
+	"sync/atomic"
+
+	"github.com/lainio/err2/internal/handler"
+	"github.com/lainio/err2/internal/x"
+)
+
+// CodeError is an error wrapper that carries a machine-readable error code in
+// the error chain. It keeps the error message as it is. You can get the code
+// with [CodeOf].
+type CodeError = handler.CodeError
+
+// CodeOrder tells [CodeOf] which code to return when there are several codes
+// in the error chain. See [SetCodeOrder].
+type CodeOrder int32
+
+const (
+	// CodeOutermost returns the code added last, i.e., the one closest to
+	// the caller. This is the default.
+	CodeOutermost CodeOrder = iota
+
+	// CodeInnermost returns the code added first, i.e., the one closest to
+	// the error source.
+	CodeInnermost
+)
+
+var codeOrder int32
+
+// SetCodeOrder sets which code [CodeOf] returns if there are several codes in
+// the error chain. The default is [CodeOutermost]. It returns the previous
+// order.
+func SetCodeOrder(order CodeOrder) (old CodeOrder) {
+	return CodeOrder(atomic.SwapInt32(&codeOrder, int32(order)))
+}
+
+// WithCode is a built-in helper to use with [Handle] and [Catch]. It wraps the
+// error with the machine-readable error code. The error message stays the
+// same. Note that error handlers disable the automatic annotation if there's
+// only one handler. You can keep it by adding [Noop] before WithCode:
+//
+//	defer err2.Handle(&err, err2.Noop, err2.WithCode("E_COPY"))
+//
+// The codes can be declared per function as well. See
+// [github.com/lainio/err2/formatter.WithCodes].
+func WithCode(code string) Handler {
+	return func(err error) error {
+		if err == nil {
+			return nil
+		}
+		return &CodeError{Code: code, Err: err}
+	}
+}
+
+// CodeOf returns the error code from the error chain. Both wrapping styles are
+// supported: single wrapping and Go 1.20 multi-wrapping (trees). The trees
+// are traversed in depth first order. If there are several codes in the chain
+// the code is selected according [SetCodeOrder]:
+//
+//	if code, ok := err2.CodeOf(err); ok {
+//	     resp.Code = code
+//	}
+func CodeOf(err error) (code string, ok bool) {
+	innermost := CodeOrder(atomic.LoadInt32(&codeOrder)) == CodeInnermost
+	x.WalkErrors(err, func(e error, _ int) bool {
+		if ce, isCode := e.(*CodeError); isCode {
+			code, ok = ce.Code, true
+			return innermost // continue search only for innermost
+		}
+		return true
+	})
+	return code, ok
+}
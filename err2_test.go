@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"runtime"
 	"testing"
 
 	"github.com/lainio/err2"
+	"github.com/lainio/err2/formatter"
 	"github.com/lainio/err2/internal/expect"
 	"github.com/lainio/err2/try"
 )
@@ -682,6 +684,161 @@ func TestSetErrRetTracer(t *testing.T) {
 	expect.That(t, w == nil, "error return tracer should be nil")
 }
 
+type multiErr []error
+
+func (m multiErr) Error() string   { return "multi error" }
+func (m multiErr) Unwrap() []error { return m }
+
+func TestCodeOf(t *testing.T) {
+	// NOTE. No Parallel, uses pkg lvl variables
+	inner := err2.WithCode("E_INNER")(errToTest)
+	outer := err2.WithCode("E_OUTER")(fmt.Errorf("outer: %w", inner))
+	tests := []struct {
+		name  string
+		err   error
+		order err2.CodeOrder
+		code  string
+		ok    bool
+	}{
+		{"nil", nil, err2.CodeOutermost, "", false},
+		{"no code", errToTest, err2.CodeOutermost, "", false},
+		{"single", inner, err2.CodeOutermost, "E_INNER", true},
+		{"outermost", outer, err2.CodeOutermost, "E_OUTER", true},
+		{"innermost", outer, err2.CodeInnermost, "E_INNER", true},
+		{"multi wrap outermost", multiErr{errToTest, outer},
+			err2.CodeOutermost, "E_OUTER", true},
+		{"multi wrap innermost", multiErr{outer, errToTest},
+			err2.CodeInnermost, "E_INNER", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer err2.SetCodeOrder(err2.SetCodeOrder(tt.order))
+			code, ok := err2.CodeOf(tt.err)
+			expect.Equal(t, ok, tt.ok)
+			expect.Equal(t, code, tt.code)
+		})
+	}
+}
+
+func TestWithCode(t *testing.T) {
+	t.Parallel()
+	sample := func() (err error) {
+		defer err2.Handle(&err, err2.Noop, err2.WithCode("E_SAMPLE"))
+		try.To1(throw())
+		return nil
+	}
+	err := sample()
+	expect.Equal(t, err.Error(), "testing: t runner: "+errStringInThrow)
+	expect.That(t, errors.Is(err, errToTest), "sentinel error should be kept")
+	code, ok := err2.CodeOf(err)
+	expect.That(t, ok)
+	expect.Equal(t, code, "E_SAMPLE")
+
+	expect.That(t, err2.WithCode("E_NIL")(nil) == nil)
+}
+
+func TestFormatterWithCodes(t *testing.T) {
+	// NOTE. No Parallel, uses pkg lvl variables
+	defer err2.SetFormatter(err2.Formatter())
+
+	// Our own test functions are skipped by the function name search. That's
+	// why the function name is the one from Go's test harness.
+	err2.SetFormatter(formatter.WithCodes(formatter.Decamel, map[string]string{
+		"testing.tRunner": "E_RUNNER",
+	}))
+	sample := func() (err error) {
+		defer err2.Handle(&err)
+		try.To1(throw())
+		return nil
+	}
+	err := sample()
+	expect.Equal(t, err.Error(), "testing: t runner: "+errStringInThrow)
+	code, ok := err2.CodeOf(err)
+	expect.That(t, ok)
+	expect.Equal(t, code, "E_RUNNER")
+}
+
+func TestFormatterWithLocation(t *testing.T) {
+	// NOTE. No Parallel, uses pkg lvl variables
+	defer err2.SetFormatter(err2.Formatter())
+
+	// Our own test functions are skipped by the function name search. That's
+	// why the location is the one from Go's test harness.
+	sample := func() (err error) {
+		defer err2.Handle(&err)
+		try.To1(throw())
+		return nil
+	}
+	located := regexp.MustCompile(`^testing: t runner \(testing\.go:\d+\): ` +
+		errStringInThrow + "$")
+
+	err2.SetFormatter(formatter.WithLocation(formatter.Decamel))
+	err := sample()
+	expect.That(t, located.MatchString(err.Error()), err)
+	_, ok := err2.CodeOf(err)
+	expect.ThatNot(t, ok)
+
+	codes := map[string]string{"testing.tRunner": "E_RUNNER"}
+	for _, f := range []formatter.Interface{
+		formatter.WithLocation(formatter.WithCodes(formatter.Decamel, codes)),
+		formatter.WithCodes(formatter.WithLocation(formatter.Decamel), codes),
+	} {
+		err2.SetFormatter(f)
+		err := sample()
+		expect.That(t, located.MatchString(err.Error()), err)
+		code, ok := err2.CodeOf(err)
+		expect.That(t, ok)
+		expect.Equal(t, code, "E_RUNNER")
+	}
+}
+
+func callRecur(d int) (err error) {
+	defer err2.Handle(&err, "call recur")
+	if d == 0 {
+		return errToTest
+	}
+	return callRecur(d - 1)
+}
+
+func TestSetAnnotationDedup(t *testing.T) {
+	// NOTE. No Parallel, uses pkg lvl variables
+	defer err2.SetAnnotationDedup(err2.SetAnnotationDedup(true))
+
+	err := callRecur(2)
+	expect.Equal(t, err.Error(), "call recur (x3): "+errStringInThrow)
+	expect.That(t, errors.Is(err, errToTest), "sentinel error should be kept")
+
+	err = callRecur(0)
+	expect.Equal(t, err.Error(), "call recur: "+errStringInThrow)
+
+	other := func() (err error) {
+		defer err2.Handle(&err, "other")
+		return callRecur(0)
+	}
+	mixed := func() (err error) {
+		defer err2.Handle(&err, "call recur")
+		return other()
+	}
+	err = mixed()
+	expect.Equal(t, err.Error(),
+		"call recur: other: call recur: "+errStringInThrow)
+
+	err2.SetAnnotationDedup(false)
+	err = callRecur(1)
+	expect.Equal(t, err.Error(), "call recur: call recur: "+errStringInThrow)
+}
+
+func ExampleCodeOf() {
+	copyFile := func() (err error) {
+		defer err2.Handle(&err, nil, err2.WithCode("E_COPY"))
+		return err2.ErrNotFound
+	}
+	err := copyFile()
+	code, _ := err2.CodeOf(err)
+	fmt.Println(code, err)
+	// Output: E_COPY not found
+}
+
 func ExampleCatch_withFmt() {
 	// Set default logger to stdout for this example
 	oldLogW := err2.LogTracer()
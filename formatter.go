@@ -3,6 +3,7 @@ package err2
 import (
 	"github.com/lainio/err2/formatter"
 	fmtstore "github.com/lainio/err2/internal/formatter"
+	"github.com/lainio/err2/internal/handler"
 )
 
 func init() {
@@ -22,6 +23,11 @@ func init() {
 //
 //	err2.SetFormatter(formatter.Noop)
 //
+// The following line adds the source location of the annotating function to
+// the automatic error messages, e.g., "copy file (main.go:37):".
+//
+//	err2.SetFormatter(formatter.WithLocation(formatter.Decamel))
+//
 // You can make your own implementations of formatters. See more information
 // in formatter package.
 func SetFormatter(f formatter.Interface) {
@@ -33,3 +39,22 @@ func SetFormatter(f formatter.Interface) {
 func Formatter() formatter.Interface {
 	return fmtstore.Formatter()
 }
+
+// SetAnnotationDedup sets the annotation deduplication mode on or off. It
+// returns the previous mode. The default is off.
+//
+// When the mode is on, the repeated identical annotations are collapsed into a
+// single annotation with the repeat count. That's useful for recursive
+// functions and for functions with several deferred [Handle] calls:
+//
+//	"call recur: call recur: call recur: root error"
+//
+// is now:
+//
+//	"call recur (x3): root error"
+//
+// Note that error wrapping still works the same, i.e., [errors.Is] and
+// [errors.As] find the original errors.
+func SetAnnotationDedup(on bool) (old bool) {
+	return handler.SetDedup(on)
+}
@@ -3,6 +3,8 @@
 package formatter
 
 import (
+	"fmt"
+
 	"github.com/lainio/err2/internal/str"
 )
 
@@ -13,6 +15,25 @@ type Interface interface {
 	Format(input string) string
 }
 
+// Locator is an optional interface for formatters that want to include the
+// source location of the annotating function, i.e., the function that has the
+// deferred err2.Handle, into the automatic error messages. See [WithLocation].
+type Locator interface {
+	Interface
+
+	// FormatLocation is called instead of Format when the source location
+	// is found. The filename is without the path.
+	FormatLocation(input, filename string, line int) string
+}
+
+// Coder is an optional interface for formatters that declare machine-readable
+// error codes for the functions. If the code is found for the annotating
+// function, the automatically annotated error is wrapped with the code. See
+// [WithCodes] and err2.CodeOf.
+type Coder interface {
+	Code(funcName string) (code string, ok bool)
+}
+
 // DoFmt is a helper function type which allows reuse Formatter struct for the
 // implementations.
 type DoFmt func(i string) string
@@ -57,3 +78,178 @@ var Noop = &Formatter{DoFmt: func(i string) string { return i }}
 func (f *Formatter) Format(input string) string {
 	return f.DoFmt(input)
 }
+
+// Location is a formatter that appends the source location of the annotating
+// function to the output of the formatter it wraps. See [WithLocation].
+type Location struct {
+	Interface
+}
+
+// WithLocation returns a formatter that appends the source location of the
+// function owning the deferred err2.Handle to the automatic error annotations.
+// That helps when many functions share the same name over the packages:
+//
+//	err2.SetFormatter(formatter.WithLocation(formatter.Decamel))
+//
+//	func CopyFile(..)  -> "copy file (main.go:37): file not exists"
+//	                       ^-----------------------^ -> 'func CopyFile' & location
+//
+// Note that the line number is the line where the function was executing when
+// the error was handled, e.g., the line of try.To call or return statement.
+//
+// The decorators compose in both orders, i.e., WithLocation(WithCodes(...))
+// keeps the codes, and WithCodes(WithLocation(...)) keeps the location.
+func WithLocation(f Interface) *Location {
+	return &Location{Interface: f}
+}
+
+// Code calls the wrapped formatter's Code if it's a [Coder]. If not, there is
+// no code.
+func (l *Location) Code(funcName string) (code string, ok bool) {
+	if c, isCoder := l.Interface.(Coder); isCoder {
+		return c.Code(funcName)
+	}
+	return "", false
+}
+
+// FormatLocation formats the input with the wrapped formatter and appends the
+// location to it.
+func (l *Location) FormatLocation(input, filename string, line int) string {
+	return fmt.Sprintf("%s (%s:%d)", l.Format(input), filename, line)
+}
+
+// Codes is a formatter that declares error codes for the functions. See
+// [WithCodes].
+type Codes struct {
+	Interface
+	codes map[string]string
+}
+
+// WithCodes returns a formatter that declares machine-readable error codes per
+// function. The function names are given as they are in the call stack, i.e.,
+// the same input that formatters get, e.g., "CopyFile" for main package and
+// "ssi.(*DIDAgent).CreateWallet" for others. The formatting is done with the
+// formatter given as an argument.
+//
+//	err2.SetFormatter(formatter.WithCodes(formatter.Decamel, map[string]string{
+//	     "CopyFile": "E_COPY",
+//	}))
+//
+// The automatically annotated errors of the CopyFile are now wrapped with the
+// code "E_COPY" that can be read with err2.CodeOf. The error messages stay the
+// same.
+func WithCodes(f Interface, codes map[string]string) *Codes {
+	c := make(map[string]string, len(codes))
+	for k, v := range codes {
+		c[k] = v
+	}
+	return &Codes{Interface: f, codes: c}
+}
+
+// Code returns the error code for the function if it's declared.
+func (c *Codes) Code(funcName string) (code string, ok bool) {
+	code, ok = c.codes[funcName]
+	return code, ok
+}
+
+// FormatLocation calls the wrapped formatter's FormatLocation if it's a
+// [Locator]. If not, it just calls Format.
+func (c *Codes) FormatLocation(input, filename string, line int) string {
+	if l, ok := c.Interface.(Locator); ok {
+		return l.FormatLocation(input, filename, line)
+	}
+	return c.Format(input)
+}
+
+// Builder is a helper to compose formatters from prefix and suffix rules, word
+// replacements, and formatter stages like [Decamel] and [Noop]. The rules are
+// processed in the following order:
+//
+//  1. prefixes and suffixes are trimmed from the raw function name,
+//  2. stages are called in the same order as they are given, [Decamel] is used
+//     if no stages are given,
+//  3. word replacements are made to the formatted output.
+//
+// For example:
+//
+//	err2.SetFormatter(formatter.NewBuilder().
+//	     TrimPrefix("Must", "do", "handle").
+//	     TrimSuffix("Impl", "Ctx").
+//	     Build())
+//
+// The above produces following error annotations:
+//
+//	func doCopyFileCtx(..)  -> "copy file: file not exists"
+//	func MustParse(..)      -> "parse: syntax error"
+//
+// Note that prefixes and suffixes respect camel case word boundaries, i.e.,
+// 'Mustard' stays as it is.
+type Builder struct {
+	prefixes []string
+	suffixes []string
+	replaces []string // pairs of old and replacement words
+	stages   []Interface
+}
+
+// NewBuilder creates a new formatter [Builder]. See its methods for more
+// information.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// TrimPrefix adds prefixes that are removed from the function names. Only the
+// first matching prefix is removed.
+func (b *Builder) TrimPrefix(prefixes ...string) *Builder {
+	b.prefixes = append(b.prefixes, prefixes...)
+	return b
+}
+
+// TrimSuffix adds suffixes that are removed from the function names. Only the
+// first matching suffix is removed.
+func (b *Builder) TrimSuffix(suffixes ...string) *Builder {
+	b.suffixes = append(b.suffixes, suffixes...)
+	return b
+}
+
+// ReplaceWord adds a word replacement rule that is run for formatted output.
+// Only the whole words are replaced. If repl is empty string the word is
+// removed.
+//
+//	formatter.NewBuilder().ReplaceWord("db", "database")
+func (b *Builder) ReplaceWord(old, repl string) *Builder {
+	b.replaces = append(b.replaces, old, repl)
+	return b
+}
+
+// Then adds a formatter stage to be called after the previous stages.
+func (b *Builder) Then(stage Interface) *Builder {
+	b.stages = append(b.stages, stage)
+	return b
+}
+
+// Build builds a new formatter from the current rules of the [Builder].
+// Builder can be used again after that without affecting the built formatter.
+func (b *Builder) Build() Interface {
+	prefixes := append([]string(nil), b.prefixes...)
+	suffixes := append([]string(nil), b.suffixes...)
+	replaces := append([]string(nil), b.replaces...)
+	stages := append([]Interface(nil), b.stages...)
+	if len(stages) == 0 {
+		stages = []Interface{Decamel}
+	}
+	return &Formatter{DoFmt: func(i string) string {
+		if len(prefixes) > 0 {
+			i = str.TrimPrefixes(i, prefixes...)
+		}
+		if len(suffixes) > 0 {
+			i = str.TrimSuffixes(i, suffixes...)
+		}
+		for _, stage := range stages {
+			i = stage.Format(i)
+		}
+		for j := 0; j < len(replaces); j += 2 {
+			i = str.ReplaceWords(i, replaces[j], replaces[j+1])
+		}
+		return i
+	}}
+}
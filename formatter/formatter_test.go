@@ -0,0 +1,53 @@
+package formatter_test
+
+import (
+	"fmt"
+
+	"github.com/lainio/err2/formatter"
+)
+
+func ExampleBuilder() {
+	f := formatter.NewBuilder().
+		TrimPrefix("Must", "do", "handle").
+		TrimSuffix("Impl", "Ctx").
+		ReplaceWord("db", "database").
+		Build()
+
+	fmt.Println(f.Format("doCopyFileCtx"))
+	fmt.Println(f.Format("store.MustOpenDB"))
+	fmt.Println(f.Format("RetryTryAgain"))
+	// Output:
+	// copy file
+	// store: open database
+	// retry try again
+}
+
+func ExampleBuilder_Then() {
+	f := formatter.NewBuilder().
+		TrimPrefix("Try").
+		Then(formatter.Noop).
+		Build()
+
+	fmt.Println(f.Format("TryCopyFile"))
+	// Output: CopyFile
+}
+
+func ExampleWithLocation() {
+	f := formatter.WithLocation(formatter.Decamel)
+
+	fmt.Println(f.FormatLocation("CopyFile", "main.go", 37))
+	fmt.Println(f.Format("CopyFile"))
+	// Output:
+	// copy file (main.go:37)
+	// copy file
+}
+
+func ExampleWithCodes() {
+	f := formatter.WithCodes(formatter.Decamel, map[string]string{
+		"CopyFile": "E_COPY",
+	})
+
+	code, ok := f.Code("CopyFile")
+	fmt.Println(f.Format("CopyFile"), code, ok)
+	// Output: copy file E_COPY true
+}
@@ -0,0 +1,39 @@
+// Package catalog implements thread safe storage for message catalog
+// interface.
+package catalog
+
+import (
+	"sync/atomic"
+
+	msgs "github.com/lainio/err2/catalog"
+)
+
+var (
+	catalog atomic.Value
+)
+
+func init() {
+	SetCatalog(msgs.English{})
+}
+
+// SetCatalog sets the current catalog. The nil resets the English catalog.
+func SetCatalog(c msgs.Interface) {
+	if c == nil {
+		c = msgs.English{}
+	}
+	catalog.Store(&c)
+}
+
+func Catalog() msgs.Interface {
+	return *catalog.Load().(*msgs.Interface)
+}
+
+// Msg returns the message of the ID from the current catalog.
+func Msg(id msgs.ID) string {
+	return Catalog().Message(id)
+}
+
+// Annotation returns the annotation from the current catalog.
+func Annotation(funcName, annotation string) string {
+	return Catalog().Annotation(funcName, annotation)
+}
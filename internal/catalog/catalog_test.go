@@ -0,0 +1,26 @@
+package catalog
+
+import (
+	"testing"
+
+	msgs "github.com/lainio/err2/catalog"
+	"github.com/lainio/err2/internal/expect"
+)
+
+type finnish struct{ msgs.English }
+
+func (finnish) Message(id msgs.ID) string { return "yhtä suuri" }
+
+// TestSetCatalogNil cannot be parallel because it sets the global catalog.
+func TestSetCatalogNil(t *testing.T) {
+	prev := Catalog()
+	t.Cleanup(func() { SetCatalog(prev) })
+
+	SetCatalog(finnish{})
+	expect.Equal(t, Msg(msgs.Equal), "yhtä suuri")
+
+	SetCatalog(nil)
+	expect.That(t, Catalog() == msgs.English{})
+	expect.Equal(t, Msg(msgs.Equal), "equal")
+	expect.Equal(t, Annotation("f", "copy file"), "copy file")
+}
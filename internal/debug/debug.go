@@ -186,6 +186,14 @@ func FuncName(si StackInfo) (n string, ln int, frame int, ok bool) {
 	return funcName(stackBuf, si)
 }
 
+// FuncLocation is similar to [FuncName] but it returns the source filename
+// (full path) of the function as well.
+func FuncLocation(si StackInfo) (n, filename string, ln int, ok bool) {
+	stackBuf := bytes.NewBuffer(debug.Stack())
+	n, filename, ln, _, ok = funcLocation(stackBuf, si)
+	return n, filename, ln, ok
+}
+
 // funcName see Funcname documentation.
 func funcName(r io.Reader,
 	si StackInfo,
@@ -194,6 +202,20 @@ func funcName(r io.Reader,
 	ln int,
 	frame int,
 	ok bool,
+) {
+	n, _, ln, frame, ok = funcLocation(r, si)
+	return n, ln, frame, ok
+}
+
+// funcLocation see FuncName and FuncLocation documentation.
+func funcLocation(r io.Reader,
+	si StackInfo,
+) (
+	n string,
+	filename string,
+	ln int,
+	frame int,
+	ok bool,
 ) {
 	var buf bytes.Buffer
 	stackBuf := io.TeeReader(r, &buf)
@@ -208,7 +230,7 @@ func funcName(r io.Reader,
 			// aka this line to get ln
 			if ok {
 				ln = fnLNro(line)
-				return n, ln, i / 2, ok
+				return n, fnFilename(line), ln, i / 2, ok
 			}
 
 			// we are interested the line before (2 x si.Level) the
@@ -223,7 +245,7 @@ func funcName(r io.Reader,
 			}
 		}
 	}
-	return n, 0, -1, false
+	return n, "", 0, -1, false
 }
 
 // notOurFunction returns true if function in call stack line isn't from err2
@@ -290,6 +312,16 @@ func fnLNro(line string) int {
 	return nro
 }
 
+// fnFilename returns source filename (full path) in the call stack line.
+func fnFilename(line string) string {
+	line = strings.TrimPrefix(line, "\t")
+	i := strings.LastIndex(line, ".go:")
+	if i == -1 {
+		return ""
+	}
+	return line[:i+len(".go")]
+}
+
 // stackPrint prints the stack trace read from reader and to the writer. The
 // StackInfo tells what it prints from the stack.
 func stackPrint(r io.Reader, w io.Writer, si StackInfo) {
@@ -203,6 +203,29 @@ func TestFnLNro(t *testing.T) {
 	}
 }
 
+func TestFnFilename(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		input  string
+		output string
+	}{
+		{"ext package",
+			"	/Users/harrilainio/go/pkg/mod/github.com/lainio/err2@v0.8.5/internal/handler/handler.go:69 +0xbc",
+			"/Users/harrilainio/go/pkg/mod/github.com/lainio/err2@v0.8.5/internal/handler/handler.go"},
+		{"no pc offset", "	/home/user/main.go:12", "/home/user/main.go"},
+		{"not a file line", "main.main()", ""},
+	}
+	for _, ttv := range tests {
+		tt := ttv
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			output := fnFilename(tt.input)
+			expect.Equal(t, output, tt.output)
+		})
+	}
+}
+
 func TestFnName(t *testing.T) {
 	t.Parallel()
 	type ttest struct {
@@ -0,0 +1,393 @@
+// Package diff implements readable structural diffs for the assert package.
+// It has no external dependencies. The values are walked with reflect, and only
+// the differing paths are reported, e.g.:
+//
+//	.Items[3].Name: "a" != "b"
+//
+// Long multi-line strings are reported as unified line diffs.
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	// MaxDiffs is the maximum amount of differences reported.
+	MaxDiffs = 20
+
+	// MaxValueLen is the maximum length of the single value in the output.
+	MaxValueLen = 64
+
+	// maxDepth is safety limit for walking, the cycles are detected anyway.
+	maxDepth = 64
+
+	// maxLines is the maximum amount of lines that are diffed per string.
+	maxLines = 500
+
+	// context is the amount of unchanged lines shown around changes.
+	context = 2
+
+	indent = "  "
+)
+
+// IsRich returns true if the values are worth of the structural diff, i.e.,
+// they are composite values or multi-line strings. For other values the
+// simple got-want message is more readable.
+func IsRich(got, want any) bool {
+	return isRich(reflect.ValueOf(got)) || isRich(reflect.ValueOf(want))
+}
+
+func isRich(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map,
+		reflect.Ptr, reflect.Interface:
+		return true
+	case reflect.String:
+		return strings.Contains(v.String(), "\n")
+	default:
+		return false
+	}
+}
+
+// Values returns the differences of the values, one difference per line. The
+// empty string is returned if there are no differences.
+func Values(got, want any) string {
+	d := differ{visited: make(map[visit]bool)}
+	d.walk("", reflect.ValueOf(got), reflect.ValueOf(want), 0)
+	return d.String()
+}
+
+// Lines returns unified line diff of the strings. The lines starting with '-'
+// are from got and the lines starting with '+' are from want.
+func Lines(got, want string) string {
+	var b strings.Builder
+	writeLines(&b, "", got, want)
+	return b.String()
+}
+
+type visit struct {
+	a, b uintptr
+	typ  reflect.Type
+}
+
+type differ struct {
+	out     []string
+	count   int
+	visited map[visit]bool
+}
+
+func (d *differ) String() string {
+	if d.count == 0 {
+		return ""
+	}
+	s := strings.Join(d.out, "\n")
+	if more := d.count - len(d.out); more > 0 {
+		s += "\n" + indent + More(more)
+	}
+	return s
+}
+
+// More returns the line that tells how many differences weren't reported
+// because of [MaxDiffs].
+func More(count int) string {
+	return fmt.Sprintf("... and %d more differences", count)
+}
+
+func (d *differ) report(path, format string, args ...any) {
+	d.count++
+	if len(d.out) >= MaxDiffs {
+		return
+	}
+	if path == "" {
+		path = "."
+	}
+	d.out = append(d.out, indent+path+": "+fmt.Sprintf(format, args...))
+}
+
+func (d *differ) reportValues(path string, a, b reflect.Value) {
+	d.report(path, "%s != %s", format(a), format(b))
+}
+
+func (d *differ) walk(path string, a, b reflect.Value, depth int) {
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			d.reportValues(path, a, b)
+		}
+		return
+	}
+	if a.Type() != b.Type() {
+		d.report(path, "type %v != %v", a.Type(), b.Type())
+		return
+	}
+	if depth > maxDepth {
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.reportValues(path, a, b)
+			}
+			return
+		}
+		if d.seen(a, b) {
+			return
+		}
+		d.walk(path, a.Elem(), b.Elem(), depth+1)
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.reportValues(path, a, b)
+			}
+			return
+		}
+		d.walk(path, a.Elem(), b.Elem(), depth+1)
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			name := a.Type().Field(i).Name
+			d.walk(path+"."+name, a.Field(i), b.Field(i), depth+1)
+		}
+	case reflect.Slice:
+		if a.IsNil() != b.IsNil() {
+			d.reportValues(path, a, b)
+			return
+		}
+		if d.seen(a, b) {
+			return
+		}
+		d.walkList(path, a, b, depth)
+	case reflect.Array:
+		d.walkList(path, a, b, depth)
+	case reflect.Map:
+		if a.IsNil() != b.IsNil() {
+			d.reportValues(path, a, b)
+			return
+		}
+		if d.seen(a, b) {
+			return
+		}
+		d.walkMap(path, a, b, depth)
+	case reflect.String:
+		as, bs := a.String(), b.String()
+		if as == bs {
+			return
+		}
+		if strings.Contains(as, "\n") || strings.Contains(bs, "\n") {
+			var sb strings.Builder
+			writeLines(&sb, indent+indent, as, bs)
+			d.report(path, "lines differ:\n%s", strings.TrimSuffix(sb.String(), "\n"))
+			return
+		}
+		d.reportValues(path, a, b)
+	case reflect.Func:
+		// like reflect.DeepEqual: funcs are equal only if both are nil
+		if !a.IsNil() || !b.IsNil() {
+			d.report(path, "func values cannot be compared")
+		}
+	default:
+		if !equalLeaf(a, b) {
+			d.reportValues(path, a, b)
+		}
+	}
+}
+
+// seen returns true if the pair of references is already visited. That's how
+// we detect the cycles.
+func (d *differ) seen(a, b reflect.Value) bool {
+	v := visit{a: a.Pointer(), b: b.Pointer(), typ: a.Type()}
+	if d.visited[v] {
+		return true
+	}
+	d.visited[v] = true
+	return false
+}
+
+func (d *differ) walkList(path string, a, b reflect.Value, depth int) {
+	al, bl := a.Len(), b.Len()
+	for i := 0; i < al || i < bl; i++ {
+		p := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= al:
+			d.report(p, "<missing> != %s", format(b.Index(i)))
+		case i >= bl:
+			d.report(p, "%s != <missing>", format(a.Index(i)))
+		default:
+			d.walk(p, a.Index(i), b.Index(i), depth+1)
+		}
+	}
+}
+
+func (d *differ) walkMap(path string, a, b reflect.Value, depth int) {
+	keys := a.MapKeys()
+	for _, k := range b.MapKeys() {
+		if !a.MapIndex(k).IsValid() {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return format(keys[i]) < format(keys[j])
+	})
+	for _, k := range keys {
+		p := fmt.Sprintf("%s[%s]", path, format(k))
+		av, bv := a.MapIndex(k), b.MapIndex(k)
+		switch {
+		case !av.IsValid():
+			d.report(p, "<missing> != %s", format(bv))
+		case !bv.IsValid():
+			d.report(p, "%s != <missing>", format(av))
+		default:
+			d.walk(p, av, bv, depth+1)
+		}
+	}
+}
+
+func equalLeaf(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	case reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	default:
+		return true
+	}
+}
+
+// format formats the value to the output. Strings are quoted and long values
+// are truncated.
+func format(v reflect.Value) (s string) {
+	switch {
+	case !v.IsValid():
+		s = "<nil>"
+	case v.Kind() == reflect.String:
+		s = fmt.Sprintf("%q", v.String())
+	case (v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.IsNil():
+		s = "nil"
+	default:
+		// fmt handles reflect.Value by printing the value it holds, and it
+		// works for unexported fields as well.
+		s = fmt.Sprintf("%v", v)
+	}
+	return Truncate(s, MaxValueLen)
+}
+
+// Truncate truncates the string to maximum length of l by adding the '...' to
+// the end of it.
+func Truncate(s string, l int) string {
+	const dots = "..."
+	r := []rune(s)
+	if len(r) <= l || l <= len(dots) {
+		return s
+	}
+	return string(r[:l-len(dots)]) + dots
+}
+
+type op byte
+
+const (
+	opSame op = ' '
+	opDel  op = '-'
+	opAdd  op = '+'
+)
+
+type edit struct {
+	op   op
+	line string
+}
+
+// writeLines writes the unified line diff to the builder. Every line is
+// prefixed with the pfx.
+func writeLines(b *strings.Builder, pfx, got, want string) {
+	a, c := strings.Split(got, "\n"), strings.Split(want, "\n")
+	truncated := false
+	if len(a) > maxLines {
+		a, truncated = a[:maxLines], true
+	}
+	if len(c) > maxLines {
+		c, truncated = c[:maxLines], true
+	}
+	edits := lcsEdits(a, c)
+
+	fmt.Fprintf(b, "%s--- got\n%s+++ want\n", pfx, pfx)
+	lastShown := -1
+	for i, e := range edits {
+		if !nearChange(edits, i) {
+			continue
+		}
+		if lastShown != -1 && i > lastShown+1 {
+			fmt.Fprintf(b, "%s...\n", pfx)
+		}
+		lastShown = i
+		fmt.Fprintf(b, "%s%c %s\n", pfx, e.op, Truncate(e.line, 2*MaxValueLen))
+	}
+	if truncated {
+		fmt.Fprintf(b, "%s... (only first %d lines compared)\n", pfx, maxLines)
+	}
+}
+
+// nearChange returns true if the edit is a change or the change is within the
+// context lines.
+func nearChange(edits []edit, i int) bool {
+	for j := i - context; j <= i+context; j++ {
+		if j >= 0 && j < len(edits) && edits[j].op != opSame {
+			return true
+		}
+	}
+	return false
+}
+
+// lcsEdits returns the edit script from a to b based on the longest common
+// subsequence.
+func lcsEdits(a, b []string) []edit {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	edits := make([]edit, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			edits = append(edits, edit{opSame, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			edits = append(edits, edit{opDel, a[i]})
+			i++
+		default:
+			edits = append(edits, edit{opAdd, b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		edits = append(edits, edit{opDel, a[i]})
+	}
+	for ; j < m; j++ {
+		edits = append(edits, edit{opAdd, b[j]})
+	}
+	return edits
+}
@@ -0,0 +1,127 @@
+package diff_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lainio/err2/internal/diff"
+	"github.com/lainio/err2/internal/expect"
+)
+
+type item struct {
+	Name  string
+	Count int
+	tags  []string
+}
+
+type order struct {
+	ID    int
+	Items []item
+	Meta  map[string]any
+	Next  *order
+}
+
+func TestValues(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		got, want any
+		out       string
+	}{
+		{"equal", order{ID: 1}, order{ID: 1}, ""},
+		{"leaf", 1, 2, "  .: 1 != 2"},
+		{"type", 1, "1", "  .: type int != string"},
+		{"nil and value", nil, 1, "  .: <nil> != 1"},
+		{"struct field",
+			order{ID: 1}, order{ID: 2},
+			"  .ID: 1 != 2"},
+		{"nested slice",
+			order{Items: []item{{Name: "a"}, {Name: "a"}}},
+			order{Items: []item{{Name: "a"}, {Name: "b"}}},
+			`  .Items[1].Name: "a" != "b"`},
+		{"unexported",
+			item{tags: []string{"x"}}, item{tags: []string{"y"}},
+			`  .tags[0]: "x" != "y"`},
+		{"missing element",
+			[]int{1, 2}, []int{1},
+			"  [1]: 2 != <missing>"},
+		{"nil slice",
+			[]int(nil), []int{},
+			"  .: nil != []"},
+		{"map",
+			map[string]int{"a": 1, "b": 2}, map[string]int{"b": 3, "c": 4},
+			`  ["a"]: 1 != <missing>` + "\n" +
+				`  ["b"]: 2 != 3` + "\n" +
+				`  ["c"]: <missing> != 4`},
+		{"interface",
+			order{Meta: map[string]any{"k": 1}},
+			order{Meta: map[string]any{"k": "1"}},
+			`  .Meta["k"]: type int != string`},
+		{"pointer",
+			&order{Next: &order{ID: 1}}, &order{Next: &order{ID: 2}},
+			"  .Next.ID: 1 != 2"},
+		{"truncated",
+			strings.Repeat("a", 100), strings.Repeat("b", 100),
+			`  .: "` + strings.Repeat("a", 60) + `... != "` +
+				strings.Repeat("b", 60) + `...`},
+	}
+	for _, ttv := range tests {
+		tt := ttv
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			expect.Equal(t, diff.Values(tt.got, tt.want), tt.out)
+		})
+	}
+}
+
+func TestValues_cycle(t *testing.T) {
+	t.Parallel()
+	a, b := &order{ID: 1}, &order{ID: 2}
+	a.Next, b.Next = a, b
+
+	expect.Equal(t, diff.Values(a, b), "  .ID: 1 != 2")
+}
+
+func TestValues_maxDiffs(t *testing.T) {
+	t.Parallel()
+	got, want := make([]int, 30), make([]int, 30)
+	for i := range want {
+		want[i] = 1
+	}
+	out := diff.Values(got, want)
+	lines := strings.Split(out, "\n")
+	expect.Equal(t, len(lines), diff.MaxDiffs+1)
+	expect.Equal(t, lines[diff.MaxDiffs], "  ... and 10 more differences")
+}
+
+func TestLines(t *testing.T) {
+	t.Parallel()
+	got := "1\n2\n3\n4\n5\n6\n7\n8"
+	want := "1\n2\n3\n4\nfive\n6\n7\n8"
+	out := diff.Lines(got, want)
+	expect.Equal(t, out, `--- got
++++ want
+  3
+  4
+- 5
++ five
+  6
+  7
+`)
+}
+
+func TestIsRich(t *testing.T) {
+	t.Parallel()
+	expect.ThatNot(t, diff.IsRich(1, 2))
+	expect.ThatNot(t, diff.IsRich("a", "b"))
+	expect.That(t, diff.IsRich("a\nb", "a"))
+	expect.That(t, diff.IsRich([]int{1}, []int{2}))
+	expect.That(t, diff.IsRich(item{}, item{}))
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+	expect.Equal(t, diff.Truncate("abcdef", 5), "ab...")
+	expect.Equal(t, diff.Truncate("abc", 5), "abc")
+	expect.Equal(t, diff.Truncate("äöåäöå", 5), "äö...")
+}
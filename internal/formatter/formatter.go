@@ -11,14 +11,17 @@ var (
 	formatter atomic.Value
 )
 
+// SetFormatter stores the formatter. Note that we store a pointer to
+// interface because atomic.Value requires the same concrete type for all the
+// stored values.
 func SetFormatter(fmter format.Interface) {
-	formatter.Store(fmter)
+	formatter.Store(&fmter)
 }
 
 func Formatter() format.Interface {
-	fmter, isInterface := formatter.Load().(format.Interface)
+	fmter, isInterface := formatter.Load().(*format.Interface)
 	if isInterface {
-		return fmter
+		return *fmter
 	}
 	return nil
 }
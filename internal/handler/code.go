@@ -0,0 +1,17 @@
+package handler
+
+// CodeError is an error wrapper that carries a machine-readable error code.
+// It doesn't change the error message, and it's transparent for errors.Is and
+// errors.As.
+type CodeError struct {
+	Code string
+	Err  error
+}
+
+func (e *CodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CodeError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/lainio/err2/internal/x"
+)
+
+var dedup int32
+
+// SetDedup sets the annotation deduplication mode on or off. It returns the
+// previous mode.
+func SetDedup(on bool) (old bool) {
+	return atomic.SwapInt32(&dedup, int32(x.Whom(on, 1, 0))) == 1
+}
+
+// Dedup returns true if the annotation deduplication mode is on.
+func Dedup() bool {
+	return atomic.LoadInt32(&dedup) == 1
+}
+
+// annotated is an annotated error that is used when deduplication mode is on.
+// It knows its annotation prefix which allows it to collapse the identical
+// annotations to one with the repeat count.
+type annotated struct {
+	prefix string
+	count  int
+	err    error
+}
+
+// newAnnotated returns a new annotated error. If the err is already annotated
+// with the same prefix, the repeat count is increased instead.
+func newAnnotated(prefix string, err error) error {
+	if a, ok := err.(*annotated); ok && a.prefix == prefix {
+		return &annotated{prefix: prefix, count: a.count + 1, err: a.err}
+	}
+	return &annotated{prefix: prefix, count: 1, err: err}
+}
+
+func (a *annotated) Error() string {
+	if a.count > 1 {
+		return fmt.Sprintf("%s (x%d): %s", a.prefix, a.count, a.err.Error())
+	}
+	return a.prefix + ": " + a.err.Error()
+}
+
+func (a *annotated) Unwrap() error {
+	return a.err
+}
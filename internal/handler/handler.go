@@ -6,8 +6,11 @@ import (
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 	"runtime"
 
+	"github.com/lainio/err2/formatter"
+	"github.com/lainio/err2/internal/catalog"
 	"github.com/lainio/err2/internal/color"
 	"github.com/lainio/err2/internal/debug"
 	fmtstore "github.com/lainio/err2/internal/formatter"
@@ -56,6 +59,10 @@ type Info struct {
 
 	werr error
 
+	// code is set if the current formatter gives an error code for the
+	// function, see formatter.Coder.
+	code string
+
 	needErrorAnnotation bool
 }
 
@@ -182,7 +189,15 @@ func (i *Info) workError() (err error) {
 }
 
 func (i *Info) fmtErr() {
-	result := fmt.Errorf(i.Format+i.wrapStr(), append(i.Args, i.werr)...)
+	var result error
+	if Dedup() {
+		result = newAnnotated(fmt.Sprintf(i.Format, i.Args...), i.werr)
+	} else {
+		result = fmt.Errorf(i.Format+i.wrapStr(), append(i.Args, i.werr)...)
+	}
+	if i.code != "" {
+		result = &CodeError{Code: i.code, Err: result}
+	}
 	i.setErrors(result)
 }
 
@@ -338,27 +353,37 @@ func PreProcess(errPtr *error, info *Info, a []any) error {
 }
 
 func buildFormatStr(info *Info, lvl int) {
-	if fs, ok := doBuildFormatStr(info, lvl); ok {
+	if fs, code, ok := doBuildFormatStr(info, lvl); ok {
 		info.Format = fs
+		info.code = code
 	}
 }
 
-func doBuildFormatStr(info *Info, lvl int) (fs string, ok bool) {
+func doBuildFormatStr(info *Info, lvl int) (fs, code string, ok bool) {
 	fnName := "Handle"
 	if info.CallerName != "" {
 		fnName = info.CallerName
 	}
-	funcName, _, _, ok := debug.FuncName(debug.StackInfo{
+	funcName, filename, ln, ok := debug.FuncLocation(debug.StackInfo{
 		PackageName: debug.Err2PackageID, // limit fn name search to err2 pkg
 		FuncName:    fnName,
 		Level:       lvl,
 	})
 	if ok {
 		setFmter := fmtstore.Formatter()
-		if setFmter != nil {
-			return setFmter.Format(funcName), true
+		switch f := setFmter.(type) {
+		case nil:
+			fs = str.Decamel(funcName)
+		case formatter.Locator:
+			filename = filepath.Base(filename)
+			fs = f.FormatLocation(funcName, filename, ln)
+		default:
+			fs = f.Format(funcName)
+		}
+		if coder, isCoder := setFmter.(formatter.Coder); isCoder {
+			code, _ = coder.Code(funcName)
 		}
-		return str.Decamel(funcName), true
+		return catalog.Annotation(funcName, fs), code, true
 	}
 	return
 }
@@ -386,9 +411,10 @@ programming error: subProcess: case 0:
 			info.ErrorFn = hfn
 			info.NilFn = hfn
 
-			if fs, ok := doBuildFormatStr(info, -1); autoOn && ok {
+			if fs, code, ok := doBuildFormatStr(info, -1); autoOn && ok {
 				//println("fmt:", fs)
 				info.Format = fs
+				info.code = code
 				info.needErrorAnnotation = true
 			}
 		}
@@ -25,8 +25,129 @@ func DecamelRegexp(str string) string {
 	return str
 }
 
+// DecamelRmTryPrefix is similar to [Decamel] but it also removes the 'Try'
+// prefixes from the function names, the lower case 'try' of the unexported
+// functions as well. Note that only real prefixes are removed, i.e.,
+// 'RetryTryAgain' stays as it is.
 func DecamelRmTryPrefix(s string) string {
-	return strings.ReplaceAll(Decamel(s), "try ", "")
+	return Decamel(TrimPrefixes(s, "Try", "try"))
+}
+
+// TrimPrefixes removes the first matching prefix from every dot separated part
+// of the function name. The prefix is removed only if it's followed by a camel
+// case word boundary, i.e., an upper case letter, a number or an underscore:
+//
+//	TrimPrefixes("pkg.MustParse", "Must") -> "pkg.Parse"
+//	TrimPrefixes("pkg.Mustard", "Must")   -> "pkg.Mustard"
+func TrimPrefixes(s string, prefixes ...string) string {
+	return mapParts(s, func(part string) string {
+		for _, prefix := range prefixes {
+			rest := strings.TrimPrefix(part, prefix)
+			if len(rest) < len(part) && isWordStart(rest) {
+				return strings.TrimPrefix(rest, "_")
+			}
+		}
+		return part
+	})
+}
+
+// TrimSuffixes removes the first matching suffix from every dot separated part
+// of the function name. The suffix is removed only if it starts a new camel
+// case word, or it's preceded by an underscore:
+//
+//	TrimSuffixes("pkg.copyImpl", "Impl") -> "pkg.copy"
+//	TrimSuffixes("pkg.simpl", "impl")    -> "pkg.simpl"
+func TrimSuffixes(s string, suffixes ...string) string {
+	return mapParts(s, func(part string) string {
+		for _, suffix := range suffixes {
+			if !strings.HasSuffix(part, suffix) || part == suffix {
+				continue
+			}
+			rest := strings.TrimSuffix(part, suffix)
+			if strings.HasSuffix(rest, "_") && rest != "_" {
+				return strings.TrimSuffix(rest, "_")
+			}
+			if isWordStart(suffix) {
+				return rest
+			}
+		}
+		return part
+	})
+}
+
+// ReplaceWords replaces all the whole word instances of old with repl. Word
+// boundaries are all the other characters than letters and numbers. If repl is
+// empty the word is removed with its following (or preceding) space:
+//
+//	ReplaceWords("retry try again", "try", "") -> "retry again"
+func ReplaceWords(s, old, repl string) string {
+	if old == "" {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for {
+		i := indexWord(s, old)
+		if i == -1 {
+			b.WriteString(s)
+			break
+		}
+		before, after := s[:i], s[i+len(old):]
+		if repl == "" {
+			if strings.HasPrefix(after, " ") {
+				after = after[1:]
+			} else {
+				before = strings.TrimSuffix(before, " ")
+			}
+		}
+		b.WriteString(before)
+		b.WriteString(repl)
+		s = after
+	}
+	return b.String()
+}
+
+// indexWord returns the index of the first whole word instance of the word in
+// s, or -1 if the word isn't present.
+func indexWord(s, word string) int {
+	for offset := 0; offset < len(s); {
+		i := strings.Index(s[offset:], word)
+		if i == -1 {
+			return -1
+		}
+		i += offset
+		end := i + len(word)
+		startOK := i == 0 || !isWordRune(rune(s[i-1]))
+		endOK := end == len(s) || !isWordRune(rune(s[end]))
+		if startOK && endOK {
+			return i
+		}
+		offset = i + 1
+	}
+	return -1
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+// isWordStart returns true if s starts a new camel case word.
+func isWordStart(s string) bool {
+	if s == "" {
+		return false
+	}
+	r := rune(s[0])
+	return unicode.IsUpper(r) || unicode.IsNumber(r) || r == '_'
+}
+
+// mapParts calls f for every dot separated part of the s and returns the
+// result joined with dots again.
+func mapParts(s string, f func(part string) string) string {
+	parts := strings.Split(s, ".")
+	for i, part := range parts {
+		parts[i] = f(part)
+	}
+	return strings.Join(parts, ".")
 }
 
 // Decamel return the given string as space delimeted. It's optimized to split
@@ -87,11 +208,21 @@ func FuncName(skip int, long bool) (n, fname string, ln int, ok bool) {
 	pc, file, ln, yes := runtime.Caller(skip + 1) // +1 skip ourself
 	if yes {
 		fn := runtime.FuncForPC(pc)
-		fname = filepath.Base(file)
-		ext := filepath.Ext(fname)
-		trimmedFilename := strings.TrimSuffix(fname, ext) + "."
-		n = strings.TrimPrefix(filepath.Base(fn.Name()), trimmedFilename)
+		frame := runtime.Frame{Function: fn.Name(), File: file, Line: ln}
+		n, fname, ln = FrameName(frame, long)
+		return n, fname, ln, yes
 	}
 	fname = x.Whom(long, file, fname)
 	return n, fname, ln, yes
 }
+
+// FrameName returns the function name, filename, and line number of the
+// frame in the same format as [FuncName].
+func FrameName(frame runtime.Frame, long bool) (n, fname string, ln int) {
+	fname = filepath.Base(frame.File)
+	ext := filepath.Ext(fname)
+	trimmedFilename := strings.TrimSuffix(fname, ext) + "."
+	n = strings.TrimPrefix(filepath.Base(frame.Function), trimmedFilename)
+	fname = x.Whom(long, frame.File, fname)
+	return n, fname, frame.Line
+}
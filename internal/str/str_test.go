@@ -133,6 +133,10 @@ func TestDecamelRmTryPrefix(t *testing.T) {
 		{"number", args{"CamelString2Testing"}, "camel string2 testing"},
 		{"acronym", args{"ARMCamelString"}, "armcamel string"},
 		{"acronym and try at END so it left", args{"ARMCamelStringTry"}, "armcamel string try"},
+		{"try in the middle so it left", args{"RetryTryAgain"}, "retry try again"},
+		{"lower case try prefix", args{"tryFoo"}, "foo"},
+		{"lower case try prefix in method", args{"pkg.(*Type).tryOpenFile"}, "pkg: type open file"},
+		{"lower case try word stays", args{"trying"}, "trying"},
 		{"acronym and try", args{"TryARMCamelString"}, "armcamel string"},
 		{"acronym at end", args{"archIsARM"}, "arch is arm"},
 		{
@@ -186,3 +190,89 @@ func TestDecamelRmTryPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestTrimPrefixes(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		s        string
+		prefixes []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{"simple", args{"MustParse", []string{"Must"}}, "Parse"},
+		{"not word boundary", args{"Mustard", []string{"Must"}}, "Mustard"},
+		{"lower case", args{"doRecur", []string{"do"}}, "Recur"},
+		{"underscore", args{"handle_error", []string{"handle"}}, "error"},
+		{"first match", args{"doHandleX", []string{"do", "Handle"}}, "HandleX"},
+		{"package", args{"pkg.MustParse", []string{"Must"}}, "pkg.Parse"},
+		{"method", args{"(*Agent).doWork", []string{"do"}}, "(*Agent).Work"},
+		{"only prefix", args{"Must", []string{"Must"}}, "Must"},
+	}
+	for _, ttv := range tests {
+		tt := ttv
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := str.TrimPrefixes(tt.args.s, tt.args.prefixes...)
+			expect.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestTrimSuffixes(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		s        string
+		suffixes []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{"simple", args{"copyImpl", []string{"Impl"}}, "copy"},
+		{"not word boundary", args{"simpl", []string{"impl"}}, "simpl"},
+		{"underscore", args{"copy_ctx", []string{"ctx"}}, "copy"},
+		{"first match", args{"copyCtxImpl", []string{"Impl", "Ctx"}}, "copyCtx"},
+		{"package", args{"pkg.CopyCtx", []string{"Ctx"}}, "pkg.Copy"},
+		{"only suffix", args{"Impl", []string{"Impl"}}, "Impl"},
+	}
+	for _, ttv := range tests {
+		tt := ttv
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := str.TrimSuffixes(tt.args.s, tt.args.suffixes...)
+			expect.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestReplaceWords(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		s, old, repl string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{"remove", args{"retry try again", "try", ""}, "retry again"},
+		{"remove last", args{"copy file try", "try", ""}, "copy file"},
+		{"remove all", args{"try try", "try", ""}, ""},
+		{"replace", args{"pkg: open db", "db", "database"}, "pkg: open database"},
+		{"not whole word", args{"dbx: open", "db", "database"}, "dbx: open"},
+		{"multi word", args{"copy file now", "copy file", "cp"}, "cp now"},
+		{"empty old", args{"copy", "", "x"}, "copy"},
+	}
+	for _, ttv := range tests {
+		tt := ttv
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := str.ReplaceWords(tt.args.s, tt.args.old, tt.args.repl)
+			expect.Equal(t, got, tt.want)
+		})
+	}
+}
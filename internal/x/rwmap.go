@@ -1,6 +1,9 @@
 package x
 
-import "sync"
+import (
+	"sync"
+	"sync/atomic"
+)
 
 // RWMap is a type for a thread-safe Go map. It tries to be short and simple.
 // Tip: It's useful to create a type alias (it allows it):
@@ -15,6 +18,7 @@ import "sync"
 type RWMap[M ~map[T]U, T comparable, U any] struct {
 	sync.RWMutex
 	m M
+	n int32 // len of m, readable without locking
 }
 
 // NewRWMap creates a new thread-safe map that's as simple as possible. The
@@ -37,6 +41,7 @@ func (m *RWMap[M, T, U]) Tx(f func(m M)) {
 	m.Lock()
 	defer m.Unlock()
 	f(m.m)
+	m.storeLen()
 }
 
 // Set sets a key value pair to the map with Go's normal map semantics.
@@ -44,6 +49,7 @@ func (m *RWMap[M, T, U]) Set(key T, val U) U {
 	m.Lock()
 	defer m.Unlock()
 	m.m[key] = val
+	m.storeLen()
 	return val
 }
 
@@ -56,6 +62,7 @@ func (m *RWMap[M, T, U]) Del(key T) U {
 	val, ok := m.m[key]
 	if ok {
 		delete(m.m, key)
+		m.storeLen()
 	}
 	return val
 }
@@ -77,3 +84,14 @@ func (m *RWMap[M, T, U]) Get(key T) U {
 	defer m.RUnlock()
 	return m.m[key]
 }
+
+// Len returns the number of the keys in the map. It doesn't lock the map,
+// which makes it cheap enough to be checked before more expensive operations,
+// e.g., if the map is empty there is no need to lock it or calculate the key.
+func (m *RWMap[M, T, U]) Len() int {
+	return int(atomic.LoadInt32(&m.n))
+}
+
+func (m *RWMap[M, T, U]) storeLen() {
+	atomic.StoreInt32(&m.n, int32(len(m.m)))
+}
@@ -41,3 +41,31 @@ func Swap[T any](lhs, rhs *T) (nlhs T) {
 	*rhs = swap
 	return *lhs
 }
+
+// WalkErrors calls f for every error in the err's chain in depth first order
+// until f returns false. Both single wrapping (Unwrap() error) and Go 1.20
+// multi-wrapping (Unwrap() []error) are supported. The depth tells how deep in
+// the chain or tree the error is, starting from 0.
+func WalkErrors(err error, f func(err error, depth int) bool) {
+	walkErrors(err, 0, f)
+}
+
+func walkErrors(err error, depth int, f func(error, int) bool) bool {
+	if err == nil {
+		return true
+	}
+	if !f(err, depth) {
+		return false
+	}
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return walkErrors(e.Unwrap(), depth+1, f)
+	case interface{ Unwrap() []error }:
+		for _, we := range e.Unwrap() {
+			if !walkErrors(we, depth+1, f) {
+				return false
+			}
+		}
+	}
+	return true
+}
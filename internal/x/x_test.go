@@ -78,6 +78,22 @@ func TestSReverse(t *testing.T) {
 	expect.That(t, reflect.DeepEqual(lengths, original))
 }
 
+func TestRWMapLen(t *testing.T) {
+	t.Parallel()
+	m := NewRWMap[map[int]string]()
+	expect.Equal(t, m.Len(), 0)
+	m.Set(1, "one")
+	m.Set(2, "two")
+	expect.Equal(t, m.Len(), 2)
+	m.Del(1)
+	m.Del(3) // not found
+	expect.Equal(t, m.Len(), 1)
+	m.Tx(func(m map[int]string) {
+		delete(m, 2)
+	})
+	expect.Equal(t, m.Len(), 0)
+}
+
 func BenchmarkSSReverse(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		SSReverse(lengths)